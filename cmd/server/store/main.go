@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/store"
+)
+
+func main() {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = server.RunMigration(os.Args[2:])
+	} else {
+		err = server.RunServer()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -8,7 +9,13 @@ import (
 )
 
 func main() {
-	if err := server.RunServer(); err != nil {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = server.RunMigration(os.Args[2:])
+	} else {
+		err = server.RunServer(context.Background())
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,33 @@
+DROP DATABASE IF EXISTS accountservice;
+CREATE DATABASE accountservice;
+USE accountservice;
+
+CREATE TABLE `accounts` (
+              `account_id` bigint(20) NOT NULL AUTO_INCREMENT,
+              `date` varchar(64) DEFAULT NULL,
+              `store_id` bigint(20) DEFAULT NULL,
+              PRIMARY KEY (`account_id`)
+);
+
+CREATE TABLE `account_details` (
+              `id` bigint(20) NOT NULL AUTO_INCREMENT,
+              `account_id` bigint(20) NOT NULL,
+              `item_id` bigint(20) NOT NULL,
+              PRIMARY KEY (`id`),
+              KEY `ACCOUNT_ID_INDEX` (`account_id`),
+              CONSTRAINT `account_details_account_id_fk` FOREIGN KEY (`account_id`)
+                  REFERENCES `accounts` (`account_id`) ON DELETE CASCADE
+);
+
+-- the schema above is the one of every migration in pkg/server/account/migrate.go
+CREATE TABLE `schema_migrations` (
+              `version` bigint NOT NULL,
+              `name` varchar(255) NOT NULL,
+              `applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (`version`)
+);
+INSERT INTO `schema_migrations`(`version`, `name`) VALUES
+              (1, 'create_accounts');
+
+CREATE USER `account-users`@`%` IDENTIFIED BY 'password';
+GRANT SELECT,INSERT,UPDATE,DELETE ON accountservice.* TO `account-users`@`%`;
@@ -0,0 +1,26 @@
+DROP DATABASE IF EXISTS bookservice;
+CREATE DATABASE bookservice;
+USE bookservice;
+
+CREATE TABLE `books` (
+              `id` bigint(20) NOT NULL AUTO_INCREMENT,
+              `title` varchar(200) DEFAULT NULL,
+              `author` varchar(200) DEFAULT NULL,
+              `description` varchar(1024) DEFAULT NULL,
+              `pages` bigint(20) DEFAULT NULL,
+              `price` bigint(20) DEFAULT NULL,
+              PRIMARY KEY (`id`)
+);
+
+-- the schema above is the one of every migration in pkg/server/book/migrate.go
+CREATE TABLE `schema_migrations` (
+              `version` bigint NOT NULL,
+              `name` varchar(255) NOT NULL,
+              `applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (`version`)
+);
+INSERT INTO `schema_migrations`(`version`, `name`) VALUES
+              (1, 'create_books');
+
+CREATE USER `book-users`@`%` IDENTIFIED BY 'password';
+GRANT SELECT,INSERT,UPDATE,DELETE ON bookservice.* TO `book-users`@`%`;
@@ -0,0 +1,5 @@
+CREATE USER item_users WITH PASSWORD 'password';
+
+DROP DATABASE IF EXISTS itemservice;
+CREATE DATABASE itemservice;
+GRANT ALL PRIVILEGES ON DATABASE itemservice TO item_users;
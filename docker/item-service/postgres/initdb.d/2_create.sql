@@ -0,0 +1,26 @@
+\c itemservice;
+CREATE SCHEMA itemschema;
+
+CREATE TABLE itemschema.items (
+              id SERIAL,
+              name varchar(200) DEFAULT NULL,
+              description varchar(1024) DEFAULT NULL,
+              price bigint DEFAULT NULL,
+              version bigint NOT NULL DEFAULT 1,
+              PRIMARY KEY (id)
+);
+
+ALTER SCHEMA itemschema OWNER TO item_users;
+ALTER TABLE itemschema.items OWNER TO item_users;
+
+-- the schema above is the one of every migration in pkg/server/item/migrate.go
+CREATE TABLE schema_migrations (
+              version bigint NOT NULL,
+              name varchar(255) NOT NULL,
+              applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (version)
+);
+INSERT INTO schema_migrations(version, name) VALUES
+              (1, 'create_items'),
+              (2, 'add_items_version');
+ALTER TABLE schema_migrations OWNER TO item_users;
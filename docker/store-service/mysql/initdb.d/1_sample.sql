@@ -0,0 +1,25 @@
+DROP DATABASE IF EXISTS storeservice;
+CREATE DATABASE storeservice;
+USE storeservice;
+
+CREATE TABLE `stores` (
+              `id` bigint(20) NOT NULL AUTO_INCREMENT,
+              `name` varchar(200) DEFAULT NULL,
+              `mail` varchar(200) DEFAULT NULL,
+              `address` varchar(1024) DEFAULT NULL,
+              PRIMARY KEY (`ID`),
+              UNIQUE KEY `ID_UNIQUE` (`ID`)
+);
+
+-- the schema above is the one of every migration in pkg/server/store/migrate.go
+CREATE TABLE `schema_migrations` (
+              `version` bigint NOT NULL,
+              `name` varchar(255) NOT NULL,
+              `applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (`version`)
+);
+INSERT INTO `schema_migrations`(`version`, `name`) VALUES
+              (1, 'create_stores');
+
+CREATE USER `store-users`@`%` IDENTIFIED BY 'password';
+GRANT SELECT,INSERT,UPDATE,DELETE ON storeservice.* TO `store-users`@`%`;
@@ -0,0 +1,5 @@
+CREATE USER store_users WITH PASSWORD 'password';
+
+DROP DATABASE IF EXISTS storeservice;
+CREATE DATABASE storeservice;
+GRANT ALL PRIVILEGES ON DATABASE storeservice TO store_users;
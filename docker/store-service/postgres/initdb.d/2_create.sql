@@ -0,0 +1,24 @@
+\c storeservice;
+CREATE SCHEMA storeschema;
+
+CREATE TABLE storeschema.stores (
+              id SERIAL,
+              name varchar(200) DEFAULT NULL,
+              mail varchar(200) DEFAULT NULL,
+              address varchar(1024) DEFAULT NULL,
+              PRIMARY KEY (id)
+);
+
+ALTER SCHEMA storeschema OWNER TO store_users;
+ALTER TABLE storeschema.stores OWNER TO store_users;
+
+-- the schema above is the one of every migration in pkg/server/store/migrate.go
+CREATE TABLE schema_migrations (
+              version bigint NOT NULL,
+              name varchar(255) NOT NULL,
+              applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (version)
+);
+INSERT INTO schema_migrations(version, name) VALUES
+              (1, 'create_stores');
+ALTER TABLE schema_migrations OWNER TO store_users;
@@ -8,9 +8,21 @@ CREATE TABLE `users` (
               `age` bigint(20) DEFAULT NULL,
               `mail` varchar(200) DEFAULT NULL,
               `address` varchar(1024) DEFAULT NULL,
+              `version` bigint(20) NOT NULL DEFAULT 1,
               PRIMARY KEY (`ID`),
               UNIQUE KEY `ID_UNIQUE` (`ID`)
 );
 
+-- the schema above is the one of every migration in pkg/server/user/migrate.go
+CREATE TABLE `schema_migrations` (
+              `version` bigint NOT NULL,
+              `name` varchar(255) NOT NULL,
+              `applied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (`version`)
+);
+INSERT INTO `schema_migrations`(`version`, `name`) VALUES
+              (1, 'create_users'),
+              (2, 'add_users_version');
+
 CREATE USER `user-users`@`%` IDENTIFIED BY 'password';
 GRANT SELECT,INSERT,UPDATE,DELETE ON userservice.* TO `user-users`@`%`;
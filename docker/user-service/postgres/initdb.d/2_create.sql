@@ -4,12 +4,25 @@ CREATE SCHEMA userschema;
 CREATE TABLE userschema.users (
               id SERIAL,
               name varchar(200) DEFAULT NULL,
-              age int DEFAULT NULL,
+              age bigint DEFAULT NULL,
               mail varchar(200) DEFAULT NULL,
               address varchar(1024) DEFAULT NULL,
+              version bigint NOT NULL DEFAULT 1,
               PRIMARY KEY (id)
 );
 
 ALTER SCHEMA userschema OWNER TO user_users;
 ALTER TABLE userschema.users OWNER TO user_users;
 
+-- the schema above is the one of every migration in pkg/server/user/migrate.go
+CREATE TABLE schema_migrations (
+              version bigint NOT NULL,
+              name varchar(255) NOT NULL,
+              applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+              PRIMARY KEY (version)
+);
+INSERT INTO schema_migrations(version, name) VALUES
+              (1, 'create_users'),
+              (2, 'widen_users_age'),
+              (3, 'add_users_version');
+ALTER TABLE schema_migrations OWNER TO user_users;
@@ -437,6 +437,8 @@ func (m *DeleteAccountResponse) GetDeleted() int64 {
 }
 
 type GetAllAccountRequest struct {
+	PageSize             int32    `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -467,8 +469,23 @@ func (m *GetAllAccountRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetAllAccountRequest proto.InternalMessageInfo
 
+func (m *GetAllAccountRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllAccountRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type GetAllAccountResponse struct {
 	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	NextPageToken        string     `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -506,6 +523,13 @@ func (m *GetAllAccountResponse) GetAccounts() []*Account {
 	return nil
 }
 
+func (m *GetAllAccountResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Account)(nil), "api.Account")
 	proto.RegisterType((*Account_Detail)(nil), "api.Account.Detail")
@@ -524,33 +548,36 @@ func init() {
 func init() { proto.RegisterFile("account-service.proto", fileDescriptor_bcbe4554547bad70) }
 
 var fileDescriptor_bcbe4554547bad70 = []byte{
-	// 405 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x4d, 0x8b, 0x9b, 0x50,
-	0x14, 0x45, 0x0d, 0x9a, 0xdc, 0x94, 0x40, 0x5f, 0x35, 0x31, 0x42, 0x21, 0xb5, 0x90, 0xba, 0x89,
-	0x90, 0x14, 0xba, 0x2a, 0x91, 0xd0, 0x40, 0xc8, 0xd6, 0xd2, 0x75, 0x71, 0xf2, 0xee, 0x42, 0x70,
-	0xa2, 0xa3, 0x2f, 0xf3, 0x9f, 0xe6, 0xbf, 0xcc, 0x8f, 0x1a, 0x7c, 0x5e, 0xf3, 0x61, 0xde, 0x62,
-	0x98, 0x9d, 0xef, 0x9e, 0x7b, 0xce, 0xb9, 0x5f, 0x82, 0x93, 0x1c, 0x0e, 0xf9, 0xe9, 0x28, 0x16,
-	0x15, 0x96, 0xcf, 0xe9, 0x01, 0xc3, 0xa2, 0xcc, 0x45, 0xce, 0x8c, 0xa4, 0x48, 0xfd, 0x17, 0x0d,
-	0xac, 0x4d, 0x03, 0xb3, 0xaf, 0x00, 0x94, 0xf9, 0x3f, 0xe5, 0xae, 0x36, 0xd3, 0x02, 0x23, 0x1e,
-	0x50, 0x64, 0xcf, 0x19, 0x83, 0x1e, 0x4f, 0x04, 0xba, 0xfa, 0x4c, 0x0b, 0x06, 0xb1, 0xfc, 0x66,
-	0x53, 0xe8, 0x57, 0x22, 0x2f, 0xb1, 0x26, 0x18, 0x92, 0x60, 0xc9, 0xf7, 0x9e, 0xb3, 0x05, 0x58,
-	0x1c, 0x45, 0x92, 0x66, 0x95, 0xdb, 0x9b, 0x19, 0xc1, 0x70, 0xf5, 0x25, 0x4c, 0x8a, 0x34, 0x24,
-	0xb3, 0x70, 0x2b, 0xb1, 0xb8, 0xcd, 0xf1, 0xbe, 0x81, 0xd9, 0x84, 0xd8, 0x04, 0xac, 0x54, 0xe0,
-	0xe3, 0xa5, 0x06, 0xb3, 0x7e, 0xee, 0xb9, 0xbf, 0x06, 0xfb, 0x4f, 0x89, 0x89, 0x40, 0xd2, 0x88,
-	0xf1, 0xe9, 0x84, 0x95, 0x60, 0x73, 0xb0, 0xa8, 0x4a, 0x49, 0x18, 0xae, 0x3e, 0x5d, 0x3b, 0xc5,
-	0x2d, 0xe8, 0xff, 0x00, 0xa7, 0xc3, 0xaf, 0x8a, 0xfc, 0x58, 0x21, 0x1b, 0x81, 0x7e, 0x36, 0xd3,
-	0x53, 0xee, 0x7f, 0x87, 0xcf, 0x3b, 0x14, 0x1d, 0x97, 0x6e, 0xd2, 0x6f, 0x60, 0xd7, 0x49, 0x24,
-	0xf5, 0xde, 0x5a, 0xd6, 0x60, 0xff, 0x2b, 0xf8, 0xc7, 0x7b, 0x59, 0x82, 0xd3, 0xe1, 0x53, 0x01,
-	0x2e, 0x58, 0x27, 0x09, 0xb4, 0xb5, 0xb6, 0x4f, 0x7f, 0x0e, 0xf6, 0x16, 0x33, 0xbc, 0xb3, 0xec,
-	0x36, 0xb6, 0x04, 0xa7, 0x93, 0x77, 0x91, 0xe6, 0x12, 0x38, 0x4b, 0xd3, 0xd3, 0x1f, 0x83, 0x5d,
-	0xcf, 0x22, 0xcb, 0x6e, 0xa5, 0xfd, 0x0d, 0x38, 0x9d, 0x38, 0x49, 0x05, 0xd0, 0xa7, 0x4e, 0x2a,
-	0x57, 0x93, 0xd7, 0x71, 0xdb, 0xe7, 0x19, 0x5d, 0xbd, 0xea, 0x30, 0xa2, 0xe8, 0xdf, 0xe6, 0x7c,
-	0x59, 0x04, 0x66, 0xb3, 0x47, 0x36, 0x95, 0x24, 0xd5, 0x51, 0x78, 0x9e, 0x0a, 0x22, 0xf7, 0x5f,
-	0x60, 0xec, 0x50, 0xb0, 0xb1, 0x4c, 0xb9, 0xdb, 0xb4, 0x37, 0xb9, 0x8b, 0x13, 0x2f, 0x02, 0xb3,
-	0x19, 0x3a, 0x19, 0xab, 0x36, 0x48, 0xc6, 0xea, 0xe5, 0x44, 0xf5, 0x91, 0xd7, 0x23, 0x23, 0x01,
-	0xd5, 0x3e, 0x48, 0x40, 0xbd, 0x82, 0x08, 0xcc, 0x66, 0xa0, 0x24, 0xa0, 0x9a, 0x3a, 0x09, 0x28,
-	0x07, 0xff, 0x60, 0xca, 0x7f, 0xff, 0xe7, 0x5b, 0x00, 0x00, 0x00, 0xff, 0xff, 0x15, 0x66, 0xdb,
-	0x07, 0x14, 0x04, 0x00, 0x00,
+	// 452 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x51, 0x8b, 0xd3, 0x40,
+	0x10, 0x26, 0xcd, 0x99, 0xb4, 0x73, 0x7a, 0xe2, 0xda, 0x78, 0xb9, 0x15, 0xa1, 0x46, 0xa8, 0x7d,
+	0xb9, 0xc2, 0x55, 0xf0, 0x49, 0xee, 0x10, 0x0f, 0x8e, 0xbe, 0x49, 0x4e, 0x9f, 0x4b, 0xec, 0x0e,
+	0xb2, 0x18, 0x93, 0x98, 0xdd, 0x8a, 0xdc, 0x3f, 0xf2, 0xbf, 0xf8, 0xa3, 0x24, 0xbb, 0x93, 0xa6,
+	0x24, 0x0b, 0xca, 0xbd, 0x75, 0xe6, 0x9b, 0xef, 0x9b, 0x6f, 0x67, 0xa6, 0x81, 0x28, 0xdb, 0x6e,
+	0xcb, 0x5d, 0xa1, 0xcf, 0x15, 0xd6, 0x3f, 0xe5, 0x16, 0x97, 0x55, 0x5d, 0xea, 0x92, 0xf9, 0x59,
+	0x25, 0x93, 0xdf, 0x1e, 0x84, 0xef, 0x2d, 0xcc, 0x5e, 0x00, 0x50, 0xe5, 0x46, 0x8a, 0xd8, 0x9b,
+	0x79, 0x0b, 0x3f, 0x9d, 0x50, 0x66, 0x2d, 0x18, 0x83, 0x23, 0x91, 0x69, 0x8c, 0x47, 0x33, 0x6f,
+	0x31, 0x49, 0xcd, 0x6f, 0x76, 0x06, 0x63, 0xa5, 0xcb, 0x1a, 0x1b, 0x82, 0x6f, 0x08, 0xa1, 0x89,
+	0xd7, 0x82, 0x9d, 0x43, 0x28, 0x50, 0x67, 0x32, 0x57, 0xf1, 0xd1, 0xcc, 0x5f, 0x1c, 0xaf, 0x9e,
+	0x2e, 0xb3, 0x4a, 0x2e, 0xa9, 0xd9, 0xf2, 0xda, 0x60, 0x69, 0x5b, 0xc3, 0x5f, 0x42, 0x60, 0x53,
+	0xec, 0x14, 0x42, 0xa9, 0xf1, 0x7b, 0xe7, 0x21, 0x68, 0xc2, 0xb5, 0x48, 0x2e, 0x61, 0xfa, 0xa1,
+	0xc6, 0x4c, 0x23, 0x69, 0xa4, 0xf8, 0x63, 0x87, 0x4a, 0xb3, 0x39, 0x84, 0xe4, 0xd2, 0x10, 0x8e,
+	0x57, 0x0f, 0x0f, 0x3b, 0xa5, 0x2d, 0x98, 0xbc, 0x86, 0xa8, 0xc7, 0x57, 0x55, 0x59, 0x28, 0x64,
+	0x27, 0x30, 0xda, 0x37, 0x1b, 0x49, 0x91, 0xbc, 0x82, 0x27, 0x37, 0xa8, 0x7b, 0x5d, 0xfa, 0x45,
+	0xef, 0x80, 0x1d, 0x16, 0x91, 0xd4, 0xff, 0x7a, 0xb9, 0x84, 0xe9, 0xe7, 0x4a, 0xdc, 0xff, 0x2d,
+	0x17, 0x10, 0xf5, 0xf8, 0x64, 0x20, 0x86, 0x70, 0x67, 0x80, 0xd6, 0x6b, 0x1b, 0x26, 0x73, 0x98,
+	0x5e, 0x63, 0x8e, 0x1a, 0xff, 0xf1, 0xb0, 0x0b, 0x88, 0x7a, 0x75, 0x9d, 0xb4, 0x30, 0xc0, 0x5e,
+	0x9a, 0xc2, 0x24, 0x85, 0x69, 0x33, 0x8b, 0x3c, 0xef, 0x49, 0x3f, 0x87, 0x49, 0x95, 0x7d, 0xc5,
+	0x8d, 0x92, 0x77, 0x68, 0x38, 0x0f, 0xd2, 0x71, 0x93, 0xb8, 0x95, 0x77, 0xd8, 0x9c, 0x9b, 0x01,
+	0x75, 0xf9, 0x0d, 0x0b, 0xba, 0x2a, 0x53, 0xfe, 0xa9, 0x49, 0x24, 0x12, 0xa2, 0x9e, 0x26, 0xd9,
+	0x58, 0xc0, 0x98, 0xa6, 0xa0, 0x62, 0x6f, 0xe6, 0x0f, 0x66, 0xb4, 0x47, 0xd9, 0x1c, 0x1e, 0x17,
+	0xf8, 0x4b, 0x6f, 0x06, 0x6d, 0x1e, 0x35, 0xe9, 0x8f, 0x6d, 0xab, 0xd5, 0x9f, 0x11, 0x9c, 0x10,
+	0xfb, 0xd6, 0xfe, 0x45, 0xd8, 0x15, 0x04, 0xf6, 0x56, 0xd8, 0x99, 0x11, 0x77, 0x1d, 0x1e, 0xe7,
+	0x2e, 0x88, 0x5c, 0xbe, 0x05, 0xff, 0x06, 0x35, 0x7b, 0x66, 0x4a, 0x06, 0xd7, 0xc4, 0x4f, 0x07,
+	0x79, 0xe2, 0x5d, 0x41, 0x60, 0x17, 0x4b, 0x8d, 0x5d, 0x57, 0xc2, 0xb9, 0x0b, 0xea, 0x04, 0xec,
+	0xfa, 0x48, 0xc0, 0xb5, 0x73, 0xce, 0x5d, 0x50, 0x27, 0x60, 0x07, 0x4f, 0x02, 0xae, 0xcd, 0x72,
+	0xee, 0x82, 0xac, 0xc0, 0x97, 0xc0, 0x7c, 0x5f, 0xde, 0xfc, 0x1d, 0x00, 0x92, 0x40, 0xd3, 0xfe,
+	0x78, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,804 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: book-service.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// bookのデータをやり取りするためのメッセージ
+type Book struct {
+	// 一意に振られるid
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 書籍のタイトル
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// 著者
+	Author string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	// 書籍の詳細
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// ページ数
+	Pages int64 `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	// 値段
+	Price                int64    `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Book) Reset()         { *m = Book{} }
+func (m *Book) String() string { return proto.CompactTextString(m) }
+func (*Book) ProtoMessage()    {}
+func (*Book) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{0}
+}
+
+func (m *Book) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Book.Unmarshal(m, b)
+}
+func (m *Book) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Book.Marshal(b, m, deterministic)
+}
+func (m *Book) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Book.Merge(m, src)
+}
+func (m *Book) XXX_Size() int {
+	return xxx_messageInfo_Book.Size(m)
+}
+func (m *Book) XXX_DiscardUnknown() {
+	xxx_messageInfo_Book.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Book proto.InternalMessageInfo
+
+func (m *Book) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Book) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Book) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Book) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Book) GetPages() int64 {
+	if m != nil {
+		return m.Pages
+	}
+	return 0
+}
+
+func (m *Book) GetPrice() int64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+// 本の情報を新しく作る時のメッセージ
+type CreateRequest struct {
+	// 書籍情報を追加する
+	Book                 *Book    `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{1}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+// 書籍情報作成時のレスポンスメッセージ
+type CreateResponse struct {
+	// 作成された書籍情報のID
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{2}
+}
+
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// 書籍情報をID指定で取得する時のメッセージ
+type GetRequest struct {
+	// 取得したい書籍のID
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{3}
+}
+
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// 書籍情報をIDで取得する場合のレスポンス
+type GetResponse struct {
+	// 取得した書籍情報
+	Book                 *Book    `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{4}
+}
+
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
+}
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+}
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
+}
+func (m *GetResponse) XXX_Size() int {
+	return xxx_messageInfo_GetResponse.Size(m)
+}
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+
+func (m *GetResponse) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+// 書籍情報を更新する場合のメッセージ
+type UpdateRequest struct {
+	// 更新したい書籍のデータ
+	Book                 *Book    `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{5}
+}
+
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetBook() *Book {
+	if m != nil {
+		return m.Book
+	}
+	return nil
+}
+
+// 書籍情報を更新した時のレスポンス
+type UpdateResponse struct {
+	// 更新件数
+	Updated              int64    `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
+func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateResponse) ProtoMessage()    {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{6}
+}
+
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateResponse.Size(m)
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+// 書籍情報を削除する場合のメッセージ
+type DeleteRequest struct {
+	// 削除する書籍のID
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{7}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// 書籍情報を削除した時のレスポンス
+type DeleteResponse struct {
+	// 削除した件数を返します
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{8}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+func (m *DeleteResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+// 全件取得する場合のメッセージ
+type GetAllRequest struct {
+	// 1ページの最大件数、0ならサーバのデフォルト
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 前のページのnext_page_token
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAllRequest) Reset()         { *m = GetAllRequest{} }
+func (m *GetAllRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAllRequest) ProtoMessage()    {}
+func (*GetAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{9}
+}
+
+func (m *GetAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllRequest.Unmarshal(m, b)
+}
+func (m *GetAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllRequest.Merge(m, src)
+}
+func (m *GetAllRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAllRequest.Size(m)
+}
+func (m *GetAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAllRequest proto.InternalMessageInfo
+
+func (m *GetAllRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// 書籍情報を全件取得した時のレスポンス
+type GetAllResponse struct {
+	// List形式で書籍情報を返す
+	Books []*Book `protobuf:"bytes,1,rep,name=books,proto3" json:"books,omitempty"`
+	// 次のページを取得するためのトークン、最後のページでは空
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAllResponse) Reset()         { *m = GetAllResponse{} }
+func (m *GetAllResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAllResponse) ProtoMessage()    {}
+func (*GetAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77c673cd08f1f90b, []int{10}
+}
+
+func (m *GetAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllResponse.Unmarshal(m, b)
+}
+func (m *GetAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllResponse.Merge(m, src)
+}
+func (m *GetAllResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAllResponse.Size(m)
+}
+func (m *GetAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAllResponse proto.InternalMessageInfo
+
+func (m *GetAllResponse) GetBooks() []*Book {
+	if m != nil {
+		return m.Books
+	}
+	return nil
+}
+
+func (m *GetAllResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Book)(nil), "api.book")
+	proto.RegisterType((*CreateRequest)(nil), "api.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "api.CreateResponse")
+	proto.RegisterType((*GetRequest)(nil), "api.GetRequest")
+	proto.RegisterType((*GetResponse)(nil), "api.GetResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "api.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "api.UpdateResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "api.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "api.DeleteResponse")
+	proto.RegisterType((*GetAllRequest)(nil), "api.GetAllRequest")
+	proto.RegisterType((*GetAllResponse)(nil), "api.GetAllResponse")
+}
+
+func init() { proto.RegisterFile("book-service.proto", fileDescriptor_77c673cd08f1f90b) }
+
+var fileDescriptor_77c673cd08f1f90b = []byte{
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xe5, 0xb8, 0x36, 0x64, 0x8c, 0x9d, 0xb2, 0xa9, 0x90, 0x65, 0x8a, 0x6a, 0xf9, 0x80,
+	0x2a, 0x0b, 0x1c, 0x14, 0x6e, 0x15, 0x17, 0x68, 0x51, 0x0e, 0x5c, 0x90, 0x0b, 0x07, 0xb8, 0x54,
+	0x6e, 0x3c, 0x0a, 0xab, 0x58, 0x5e, 0x63, 0x6f, 0x10, 0x2a, 0xea, 0xa5, 0x8f, 0x00, 0x8f, 0xc6,
+	0x2b, 0xf0, 0x20, 0x68, 0xff, 0xd1, 0x6e, 0x00, 0xc1, 0x25, 0xd2, 0xfc, 0x66, 0xe6, 0x9b, 0x6f,
+	0xb2, 0x23, 0x03, 0x39, 0x67, 0x6c, 0xfd, 0x78, 0xc0, 0xfe, 0x13, 0x5d, 0x62, 0xd1, 0xf5, 0x8c,
+	0x33, 0xe2, 0x56, 0x1d, 0x4d, 0xf6, 0x57, 0x8c, 0xad, 0x1a, 0x9c, 0x55, 0x1d, 0x9d, 0x55, 0x6d,
+	0xcb, 0x78, 0xc5, 0x29, 0x6b, 0x07, 0x55, 0x92, 0x7d, 0x75, 0x60, 0x47, 0x74, 0x92, 0x08, 0x46,
+	0xb4, 0x8e, 0x9d, 0xd4, 0x39, 0x74, 0xcb, 0x11, 0xad, 0xc9, 0x1e, 0x78, 0x9c, 0xf2, 0x06, 0xe3,
+	0x51, 0xea, 0x1c, 0x8e, 0x4b, 0x15, 0x90, 0x7b, 0xe0, 0x57, 0x1b, 0xfe, 0x81, 0xf5, 0xb1, 0x2b,
+	0xb1, 0x8e, 0x48, 0x0a, 0x41, 0x8d, 0xc3, 0xb2, 0xa7, 0x9d, 0x10, 0x8f, 0x77, 0x64, 0xf2, 0x26,
+	0x12, 0x7a, 0x5d, 0xb5, 0xc2, 0x21, 0xf6, 0xe4, 0x08, 0x15, 0x48, 0xda, 0xd3, 0x25, 0xc6, 0xbe,
+	0xa6, 0x22, 0xc8, 0x0a, 0x08, 0x8f, 0x7b, 0xac, 0x38, 0x96, 0xf8, 0x71, 0x83, 0x03, 0x27, 0x0f,
+	0x94, 0x49, 0x69, 0x2f, 0x98, 0x8f, 0x8b, 0xaa, 0xa3, 0x85, 0x00, 0xa5, 0xc4, 0x59, 0x0a, 0x91,
+	0xa9, 0x1f, 0x3a, 0xd6, 0x0e, 0xb8, 0xbd, 0x4d, 0xb6, 0x0f, 0xb0, 0x40, 0x6e, 0xe4, 0xb6, 0xb3,
+	0x8f, 0x20, 0x90, 0x59, 0xdd, 0xfc, 0x8f, 0x69, 0x05, 0x84, 0x6f, 0xbb, 0xfa, 0xff, 0xdd, 0xe5,
+	0x10, 0x99, 0x7a, 0x3d, 0x20, 0x86, 0x5b, 0x1b, 0x49, 0x8c, 0x09, 0x13, 0x66, 0x07, 0x10, 0x9e,
+	0x60, 0x83, 0x1c, 0xff, 0x66, 0x35, 0x87, 0xc8, 0x14, 0x5c, 0x8b, 0xd5, 0x92, 0xfc, 0x12, 0xd3,
+	0x61, 0xf6, 0x0a, 0xc2, 0x05, 0xf2, 0xe7, 0x4d, 0x63, 0xc4, 0xee, 0xc3, 0x58, 0xfc, 0xed, 0x67,
+	0x03, 0xbd, 0x40, 0x59, 0xec, 0x95, 0xb7, 0x05, 0x38, 0xa5, 0x17, 0x62, 0x6b, 0x90, 0x49, 0xce,
+	0xd6, 0xd8, 0xea, 0x57, 0x97, 0xe5, 0x6f, 0x04, 0xc8, 0xde, 0x41, 0x64, 0xc4, 0xf4, 0xe0, 0x03,
+	0xf0, 0xc4, 0x7e, 0x43, 0xec, 0xa4, 0xae, 0xbd, 0xb7, 0xe2, 0xe4, 0x21, 0x4c, 0x5a, 0xfc, 0xcc,
+	0xcf, 0x7e, 0x93, 0x0d, 0x05, 0x7e, 0x6d, 0xa4, 0xe7, 0x57, 0x2e, 0x04, 0x2f, 0x18, 0x5b, 0x9f,
+	0xaa, 0xe3, 0x25, 0x27, 0xe0, 0xab, 0x51, 0x84, 0x48, 0x4d, 0x6b, 0x89, 0x64, 0x6a, 0x31, 0xe5,
+	0x25, 0xbb, 0x7b, 0xf5, 0xfd, 0xc7, 0xb7, 0x51, 0x40, 0xc6, 0x33, 0x31, 0x7a, 0x56, 0x35, 0x0d,
+	0x39, 0x06, 0x5f, 0x1d, 0x85, 0x56, 0xb1, 0x2e, 0x2a, 0x99, 0x5a, 0x4c, 0xab, 0xec, 0x4a, 0x15,
+	0xc8, 0x3c, 0xa9, 0x72, 0xe4, 0xe4, 0xe4, 0x19, 0xb8, 0x0b, 0xe4, 0x64, 0x62, 0x66, 0x9a, 0xf6,
+	0xdd, 0x6b, 0xa0, 0x7b, 0x89, 0xec, 0xbd, 0x43, 0x40, 0x39, 0xf8, 0x42, 0xeb, 0x4b, 0xb2, 0x04,
+	0x5f, 0xbd, 0xbc, 0xb6, 0x60, 0x9d, 0x4d, 0x32, 0xb5, 0x98, 0x96, 0x79, 0x22, 0x65, 0xf2, 0x64,
+	0xa2, 0x65, 0xc4, 0x6f, 0x41, 0xeb, 0xcb, 0x23, 0x27, 0x7f, 0xbf, 0x37, 0xff, 0x03, 0x25, 0x2f,
+	0xc1, 0x57, 0x17, 0xa1, 0x87, 0x58, 0xf7, 0x93, 0x4c, 0x2d, 0x66, 0x7b, 0xcd, 0x6f, 0x78, 0x3d,
+	0xf7, 0xe5, 0xf7, 0xe0, 0xe9, 0xcf, 0x01, 0x00, 0xb7, 0xc6, 0x2a, 0xda, 0x48, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BookServiceClient is the client API for BookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BookServiceClient interface {
+	// 書籍情報の全件取得用のRPCメソッド
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error)
+	// 書籍情報作成用のRPCメソッド
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// 書籍情報１件取得用のRPCメソッド
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// 書籍情報の更新用のRPCメソッド
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// 書籍情報の削除用のRPCメソッド
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type bookServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBookServiceClient(cc *grpc.ClientConn) BookServiceClient {
+	return &bookServiceClient{cc}
+}
+
+func (c *bookServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error) {
+	out := new(GetAllResponse)
+	err := c.cc.Invoke(ctx, "/api.BookService/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/api.BookService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/api.BookService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/api.BookService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/api.BookService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BookServiceServer is the server API for BookService service.
+type BookServiceServer interface {
+	// 書籍情報の全件取得用のRPCメソッド
+	GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	// 書籍情報作成用のRPCメソッド
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// 書籍情報１件取得用のRPCメソッド
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// 書籍情報の更新用のRPCメソッド
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// 書籍情報の削除用のRPCメソッド
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+}
+
+func RegisterBookServiceServer(s *grpc.Server, srv BookServiceServer) {
+	s.RegisterService(&_BookService_serviceDesc, srv)
+}
+
+func _BookService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.BookService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).GetAll(ctx, req.(*GetAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.BookService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.BookService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.BookService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.BookService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.BookService",
+	HandlerType: (*BookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _BookService_GetAll_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _BookService_Create_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _BookService_Get_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _BookService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BookService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "book-service.proto",
+}
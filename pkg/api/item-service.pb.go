@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -27,6 +29,7 @@ type Item struct {
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	Price                int64    `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	Version              int64    `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -85,6 +88,13 @@ func (m *Item) GetPrice() int64 {
 	return 0
 }
 
+func (m *Item) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type CreateItemRequest struct {
 	Item                 *Item    `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -242,10 +252,15 @@ func (m *GetItemResponse) GetItem() *Item {
 }
 
 type UpdateItemRequest struct {
-	Item                 *Item    `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// item.version, when not 0, must be the stored version or the update
+	// fails with ABORTED
+	Item *Item `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	// Fields of item to write, one or more of name, description, price.
+	// Every field is written when unset or "*".
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *UpdateItemRequest) Reset()         { *m = UpdateItemRequest{} }
@@ -280,6 +295,13 @@ func (m *UpdateItemRequest) GetItem() *Item {
 	return nil
 }
 
+func (m *UpdateItemRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type UpdateItemResponse struct {
 	Updated              int64    `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -321,6 +343,7 @@ func (m *UpdateItemResponse) GetUpdated() int64 {
 
 type DeleteItemRequest struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -358,6 +381,13 @@ func (m *DeleteItemRequest) GetId() int64 {
 	return 0
 }
 
+func (m *DeleteItemRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type DeleteItemResponse struct {
 	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -397,17 +427,77 @@ func (m *DeleteItemResponse) GetDeleted() int64 {
 	return 0
 }
 
+// ItemFilter : conditions of an item listing, unset fields match every item
+type ItemFilter struct {
+	NamePrefix           string               `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	MinPrice             *wrappers.Int64Value `protobuf:"bytes,2,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice             *wrappers.Int64Value `protobuf:"bytes,3,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ItemFilter) Reset()         { *m = ItemFilter{} }
+func (m *ItemFilter) String() string { return proto.CompactTextString(m) }
+func (*ItemFilter) ProtoMessage()    {}
+func (*ItemFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{9}
+}
+
+func (m *ItemFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ItemFilter.Unmarshal(m, b)
+}
+func (m *ItemFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ItemFilter.Marshal(b, m, deterministic)
+}
+func (m *ItemFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ItemFilter.Merge(m, src)
+}
+func (m *ItemFilter) XXX_Size() int {
+	return xxx_messageInfo_ItemFilter.Size(m)
+}
+func (m *ItemFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ItemFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ItemFilter proto.InternalMessageInfo
+
+func (m *ItemFilter) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+func (m *ItemFilter) GetMinPrice() *wrappers.Int64Value {
+	if m != nil {
+		return m.MinPrice
+	}
+	return nil
+}
+
+func (m *ItemFilter) GetMaxPrice() *wrappers.Int64Value {
+	if m != nil {
+		return m.MaxPrice
+	}
+	return nil
+}
+
 type GetAllItemRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageSize             int32       `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               *ItemFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string      `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *GetAllItemRequest) Reset()         { *m = GetAllItemRequest{} }
 func (m *GetAllItemRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllItemRequest) ProtoMessage()    {}
 func (*GetAllItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ddda6238c898b818, []int{9}
+	return fileDescriptor_ddda6238c898b818, []int{10}
 }
 
 func (m *GetAllItemRequest) XXX_Unmarshal(b []byte) error {
@@ -428,8 +518,37 @@ func (m *GetAllItemRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetAllItemRequest proto.InternalMessageInfo
 
+func (m *GetAllItemRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllItemRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetAllItemRequest) GetFilter() *ItemFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *GetAllItemRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
 type GetAllItemResponse struct {
 	Items                []*Item  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -439,7 +558,7 @@ func (m *GetAllItemResponse) Reset()         { *m = GetAllItemResponse{} }
 func (m *GetAllItemResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAllItemResponse) ProtoMessage()    {}
 func (*GetAllItemResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ddda6238c898b818, []int{10}
+	return fileDescriptor_ddda6238c898b818, []int{11}
 }
 
 func (m *GetAllItemResponse) XXX_Unmarshal(b []byte) error {
@@ -467,6 +586,341 @@ func (m *GetAllItemResponse) GetItems() []*Item {
 	return nil
 }
 
+func (m *GetAllItemResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListItemsRequest struct {
+	Filter               *ItemFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string      `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListItemsRequest) Reset()         { *m = ListItemsRequest{} }
+func (m *ListItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListItemsRequest) ProtoMessage()    {}
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{12}
+}
+
+func (m *ListItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListItemsRequest.Unmarshal(m, b)
+}
+func (m *ListItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListItemsRequest.Merge(m, src)
+}
+func (m *ListItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListItemsRequest.Size(m)
+}
+func (m *ListItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListItemsRequest proto.InternalMessageInfo
+
+func (m *ListItemsRequest) GetFilter() *ItemFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListItemsRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+type BatchCreateItemsRequest struct {
+	Items                []*Item  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchCreateItemsRequest) Reset()         { *m = BatchCreateItemsRequest{} }
+func (m *BatchCreateItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateItemsRequest) ProtoMessage()    {}
+func (*BatchCreateItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{13}
+}
+
+func (m *BatchCreateItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateItemsRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateItemsRequest.Merge(m, src)
+}
+func (m *BatchCreateItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateItemsRequest.Size(m)
+}
+func (m *BatchCreateItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateItemsRequest proto.InternalMessageInfo
+
+func (m *BatchCreateItemsRequest) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BatchCreateItemsResponse struct {
+	Ids                  []int64  `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchCreateItemsResponse) Reset()         { *m = BatchCreateItemsResponse{} }
+func (m *BatchCreateItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateItemsResponse) ProtoMessage()    {}
+func (*BatchCreateItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{14}
+}
+
+func (m *BatchCreateItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateItemsResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateItemsResponse.Merge(m, src)
+}
+func (m *BatchCreateItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateItemsResponse.Size(m)
+}
+func (m *BatchCreateItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateItemsResponse proto.InternalMessageInfo
+
+func (m *BatchCreateItemsResponse) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type BatchGetItemsRequest struct {
+	Ids                  []int64  `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetItemsRequest) Reset()         { *m = BatchGetItemsRequest{} }
+func (m *BatchGetItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetItemsRequest) ProtoMessage()    {}
+func (*BatchGetItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{15}
+}
+
+func (m *BatchGetItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetItemsRequest.Unmarshal(m, b)
+}
+func (m *BatchGetItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchGetItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetItemsRequest.Merge(m, src)
+}
+func (m *BatchGetItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchGetItemsRequest.Size(m)
+}
+func (m *BatchGetItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetItemsRequest proto.InternalMessageInfo
+
+func (m *BatchGetItemsRequest) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type ItemResult struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Item                 *Item    `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ItemResult) Reset()         { *m = ItemResult{} }
+func (m *ItemResult) String() string { return proto.CompactTextString(m) }
+func (*ItemResult) ProtoMessage()    {}
+func (*ItemResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{16}
+}
+
+func (m *ItemResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ItemResult.Unmarshal(m, b)
+}
+func (m *ItemResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ItemResult.Marshal(b, m, deterministic)
+}
+func (m *ItemResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ItemResult.Merge(m, src)
+}
+func (m *ItemResult) XXX_Size() int {
+	return xxx_messageInfo_ItemResult.Size(m)
+}
+func (m *ItemResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ItemResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ItemResult proto.InternalMessageInfo
+
+func (m *ItemResult) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ItemResult) GetItem() *Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+type BatchGetItemsResponse struct {
+	Results              []*ItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BatchGetItemsResponse) Reset()         { *m = BatchGetItemsResponse{} }
+func (m *BatchGetItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetItemsResponse) ProtoMessage()    {}
+func (*BatchGetItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{17}
+}
+
+func (m *BatchGetItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetItemsResponse.Unmarshal(m, b)
+}
+func (m *BatchGetItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchGetItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetItemsResponse.Merge(m, src)
+}
+func (m *BatchGetItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchGetItemsResponse.Size(m)
+}
+func (m *BatchGetItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetItemsResponse proto.InternalMessageInfo
+
+func (m *BatchGetItemsResponse) GetResults() []*ItemResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchDeleteItemsRequest struct {
+	Items                []*DeleteItemRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BatchDeleteItemsRequest) Reset()         { *m = BatchDeleteItemsRequest{} }
+func (m *BatchDeleteItemsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteItemsRequest) ProtoMessage()    {}
+func (*BatchDeleteItemsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{18}
+}
+
+func (m *BatchDeleteItemsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteItemsRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteItemsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteItemsRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteItemsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteItemsRequest.Merge(m, src)
+}
+func (m *BatchDeleteItemsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteItemsRequest.Size(m)
+}
+func (m *BatchDeleteItemsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteItemsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteItemsRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteItemsRequest) GetItems() []*DeleteItemRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BatchDeleteItemsResponse struct {
+	Deleted              []int64  `protobuf:"varint,1,rep,packed,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteItemsResponse) Reset()         { *m = BatchDeleteItemsResponse{} }
+func (m *BatchDeleteItemsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteItemsResponse) ProtoMessage()    {}
+func (*BatchDeleteItemsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ddda6238c898b818, []int{19}
+}
+
+func (m *BatchDeleteItemsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteItemsResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteItemsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteItemsResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteItemsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteItemsResponse.Merge(m, src)
+}
+func (m *BatchDeleteItemsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteItemsResponse.Size(m)
+}
+func (m *BatchDeleteItemsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteItemsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteItemsResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteItemsResponse) GetDeleted() []int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Item)(nil), "api.Item")
 	proto.RegisterType((*CreateItemRequest)(nil), "api.CreateItemRequest")
@@ -477,37 +931,73 @@ func init() {
 	proto.RegisterType((*UpdateItemResponse)(nil), "api.UpdateItemResponse")
 	proto.RegisterType((*DeleteItemRequest)(nil), "api.DeleteItemRequest")
 	proto.RegisterType((*DeleteItemResponse)(nil), "api.DeleteItemResponse")
+	proto.RegisterType((*ItemFilter)(nil), "api.ItemFilter")
 	proto.RegisterType((*GetAllItemRequest)(nil), "api.GetAllItemRequest")
 	proto.RegisterType((*GetAllItemResponse)(nil), "api.GetAllItemResponse")
+	proto.RegisterType((*ListItemsRequest)(nil), "api.ListItemsRequest")
+	proto.RegisterType((*BatchCreateItemsRequest)(nil), "api.BatchCreateItemsRequest")
+	proto.RegisterType((*BatchCreateItemsResponse)(nil), "api.BatchCreateItemsResponse")
+	proto.RegisterType((*BatchGetItemsRequest)(nil), "api.BatchGetItemsRequest")
+	proto.RegisterType((*ItemResult)(nil), "api.ItemResult")
+	proto.RegisterType((*BatchGetItemsResponse)(nil), "api.BatchGetItemsResponse")
+	proto.RegisterType((*BatchDeleteItemsRequest)(nil), "api.BatchDeleteItemsRequest")
+	proto.RegisterType((*BatchDeleteItemsResponse)(nil), "api.BatchDeleteItemsResponse")
 }
 
 func init() { proto.RegisterFile("item-service.proto", fileDescriptor_ddda6238c898b818) }
 
 var fileDescriptor_ddda6238c898b818 = []byte{
-	// 355 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x4b, 0xc3, 0x40,
-	0x10, 0x25, 0x1f, 0x8d, 0x74, 0x0a, 0x95, 0x4c, 0x8b, 0x86, 0x82, 0x18, 0x56, 0x0f, 0xbd, 0x18,
-	0x4a, 0xc4, 0x43, 0x8e, 0xa2, 0x50, 0xbc, 0x56, 0xfc, 0x01, 0x69, 0x33, 0x87, 0x85, 0xb6, 0x59,
-	0x93, 0xad, 0x7f, 0xca, 0x3f, 0x29, 0xd9, 0xdd, 0xd6, 0x4d, 0x52, 0x10, 0x6f, 0x99, 0x37, 0xf3,
-	0xde, 0x9b, 0xbc, 0x49, 0x00, 0xb9, 0xa4, 0xdd, 0x43, 0x4d, 0xd5, 0x17, 0xdf, 0x50, 0x22, 0xaa,
-	0x52, 0x96, 0xe8, 0xe5, 0x82, 0xb3, 0x35, 0xf8, 0x6f, 0x92, 0x76, 0x38, 0x06, 0x97, 0x17, 0x91,
-	0x13, 0x3b, 0x73, 0x6f, 0xe5, 0xf2, 0x02, 0x11, 0xfc, 0x7d, 0xbe, 0xa3, 0xc8, 0x8d, 0x9d, 0xf9,
-	0x70, 0xa5, 0x9e, 0x31, 0x86, 0x51, 0x41, 0xf5, 0xa6, 0xe2, 0x42, 0xf2, 0x72, 0x1f, 0x79, 0xaa,
-	0x65, 0x43, 0x38, 0x85, 0x81, 0xa8, 0xf8, 0x86, 0x22, 0x5f, 0x09, 0xe9, 0x82, 0xa5, 0x10, 0xbe,
-	0x54, 0x94, 0x4b, 0x6a, 0x9c, 0x56, 0xf4, 0x79, 0xa0, 0x5a, 0xe2, 0x0d, 0xf8, 0xcd, 0x4e, 0xca,
-	0x72, 0x94, 0x0e, 0x93, 0x5c, 0xf0, 0x44, 0xf5, 0x15, 0xcc, 0xee, 0x01, 0x6d, 0x4e, 0x2d, 0xca,
-	0x7d, 0x4d, 0xdd, 0x2d, 0x59, 0x0c, 0xe3, 0x25, 0x49, 0x5b, 0xb6, 0x3b, 0xb1, 0x80, 0xcb, 0xd3,
-	0x84, 0x11, 0xf9, 0xc3, 0x39, 0x85, 0xf0, 0x43, 0x14, 0xff, 0xdb, 0x36, 0x01, 0xb4, 0x39, 0xc6,
-	0x28, 0x82, 0x8b, 0x83, 0x42, 0x8f, 0x0b, 0x1d, 0x4b, 0x76, 0x07, 0xe1, 0x2b, 0x6d, 0xa9, 0xed,
-	0xd1, 0x5d, 0x3d, 0x01, 0xb4, 0x87, 0x7e, 0x45, 0x0b, 0x85, 0x9e, 0x44, 0x4d, 0xc9, 0x26, 0x10,
-	0x2e, 0x49, 0x3e, 0x6f, 0xb7, 0x96, 0x28, 0x7b, 0x02, 0xb4, 0x41, 0x23, 0x72, 0x0b, 0x83, 0x66,
-	0xef, 0x3a, 0x72, 0x62, 0xaf, 0xfd, 0x3e, 0x1a, 0x4f, 0xbf, 0x5d, 0x18, 0x35, 0xf5, 0xbb, 0xfe,
-	0x62, 0x30, 0x83, 0x40, 0x9f, 0x03, 0xaf, 0xd4, 0x6c, 0xef, 0x9e, 0xb3, 0xeb, 0x1e, 0x6e, 0xbc,
-	0x16, 0xe0, 0x2d, 0x49, 0xe2, 0x44, 0xf5, 0xdb, 0xd7, 0x9a, 0x4d, 0xdb, 0xa0, 0x61, 0x64, 0x10,
-	0xe8, 0x34, 0x8d, 0x59, 0xef, 0x1c, 0xc6, 0xec, 0x4c, 0xe4, 0x19, 0x04, 0x3a, 0x33, 0x43, 0xed,
-	0xa5, 0x6c, 0xa8, 0x67, 0x82, 0xcd, 0x20, 0xd0, 0x49, 0x19, 0x6a, 0x2f, 0x4b, 0x43, 0xed, 0xc7,
-	0xb9, 0x0e, 0xd4, 0x0f, 0xf5, 0xf8, 0x13, 0x00, 0x00, 0xff, 0xff, 0x53, 0xf8, 0x9f, 0x58, 0x66,
-	0x03, 0x00, 0x00,
+	// 794 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xeb, 0x4e, 0xdb, 0x48,
+	0x14, 0x96, 0xe3, 0x5c, 0xc8, 0x89, 0xb8, 0x64, 0x16, 0x36, 0xc6, 0x2c, 0x4b, 0x64, 0xad, 0x76,
+	0x59, 0x89, 0x35, 0x28, 0x8b, 0x56, 0xcb, 0xa2, 0xfd, 0x51, 0x5a, 0x11, 0x21, 0xb5, 0x2a, 0x32,
+	0x2d, 0xff, 0xaa, 0xc8, 0xc4, 0x27, 0x74, 0x84, 0x63, 0xbb, 0xf6, 0x84, 0x06, 0xd4, 0xb7, 0xe8,
+	0x13, 0xf4, 0x65, 0xfa, 0x5c, 0xd5, 0x5c, 0x1c, 0xdb, 0x31, 0x29, 0xf4, 0x9f, 0xe7, 0x3b, 0xb7,
+	0xef, 0x9c, 0x39, 0xdf, 0x18, 0x08, 0x65, 0x38, 0xfe, 0x2b, 0xc1, 0xf8, 0x96, 0x0e, 0xd1, 0x8e,
+	0xe2, 0x90, 0x85, 0x44, 0x77, 0x23, 0x6a, 0x76, 0xaf, 0xc3, 0xf0, 0xda, 0xc7, 0x7d, 0x01, 0x5d,
+	0x4d, 0x46, 0xfb, 0x23, 0x8a, 0xbe, 0x37, 0x18, 0xbb, 0xc9, 0x8d, 0x74, 0x33, 0x7f, 0x9d, 0xf7,
+	0xf8, 0x18, 0xbb, 0x51, 0x84, 0x71, 0x22, 0xed, 0xd6, 0x27, 0xa8, 0x9e, 0x31, 0x1c, 0x93, 0x15,
+	0xa8, 0x50, 0xcf, 0xd0, 0xba, 0xda, 0xae, 0xee, 0x54, 0xa8, 0x47, 0x08, 0x54, 0x03, 0x77, 0x8c,
+	0x46, 0xa5, 0xab, 0xed, 0x36, 0x1d, 0xf1, 0x4d, 0xba, 0xd0, 0xf2, 0x30, 0x19, 0xc6, 0x34, 0x62,
+	0x34, 0x0c, 0x0c, 0x5d, 0x98, 0xf2, 0x10, 0x59, 0x87, 0x5a, 0x14, 0xd3, 0x21, 0x1a, 0x55, 0x91,
+	0x48, 0x1e, 0x88, 0x01, 0x8d, 0x5b, 0x8c, 0x13, 0x1e, 0x53, 0x13, 0x78, 0x7a, 0xb4, 0x7a, 0xd0,
+	0x7e, 0x1e, 0xa3, 0xcb, 0x90, 0x73, 0x70, 0xf0, 0xc3, 0x04, 0x13, 0x46, 0xb6, 0xa1, 0xca, 0xfb,
+	0x15, 0x64, 0x5a, 0xbd, 0xa6, 0xed, 0x46, 0xd4, 0x16, 0x76, 0x01, 0x5b, 0xbf, 0x01, 0xc9, 0xc7,
+	0x24, 0x51, 0x18, 0x24, 0x38, 0xcf, 0xdf, 0xea, 0xc2, 0x4a, 0x1f, 0x59, 0x3e, 0xed, 0xbc, 0xc7,
+	0x01, 0xac, 0xce, 0x3c, 0x54, 0x92, 0x47, 0x2a, 0x87, 0xd0, 0x7e, 0x1b, 0x79, 0x3f, 0xc4, 0x96,
+	0x1c, 0x43, 0x6b, 0x22, 0x62, 0xc4, 0xa5, 0x88, 0x71, 0xb6, 0x7a, 0xa6, 0x2d, 0x6f, 0xc5, 0x4e,
+	0x6f, 0xc5, 0x3e, 0xe5, 0xf7, 0xf6, 0xca, 0x4d, 0x6e, 0x1c, 0x90, 0xee, 0xfc, 0xdb, 0xb2, 0x81,
+	0xe4, 0x0b, 0x2a, 0x96, 0x06, 0x34, 0xa4, 0x4f, 0xda, 0x4d, 0x7a, 0xb4, 0xfe, 0x87, 0xf6, 0x0b,
+	0xf4, 0x91, 0xe1, 0x77, 0xfa, 0xce, 0xdf, 0x46, 0xa5, 0x78, 0x1b, 0x36, 0x90, 0x7c, 0x78, 0x56,
+	0xce, 0x13, 0xe8, 0xac, 0x9c, 0x3a, 0x5a, 0x5f, 0x34, 0x00, 0xee, 0x7a, 0x4a, 0x7d, 0x86, 0x31,
+	0xd9, 0x81, 0x16, 0x5f, 0x93, 0x41, 0x14, 0xe3, 0x88, 0x4e, 0x85, 0x73, 0xd3, 0x01, 0x0e, 0x9d,
+	0x0b, 0x84, 0xfc, 0x0b, 0xcd, 0x31, 0x0d, 0x06, 0x72, 0x43, 0xe4, 0x24, 0xb6, 0x4a, 0x93, 0x38,
+	0x0b, 0xd8, 0x3f, 0x87, 0x97, 0xae, 0x3f, 0x41, 0x67, 0x69, 0x4c, 0x83, 0x73, 0xb1, 0x41, 0x3c,
+	0xd2, 0x9d, 0xaa, 0x48, 0xfd, 0x29, 0x91, 0xee, 0x54, 0x44, 0x5a, 0x9f, 0x35, 0x68, 0xf7, 0x91,
+	0x3d, 0xf3, 0xfd, 0xfc, 0x4c, 0xb6, 0xa0, 0x19, 0xb9, 0xd7, 0x38, 0x48, 0xe8, 0x3d, 0x0a, 0xa2,
+	0x35, 0x67, 0x89, 0x03, 0x17, 0xf4, 0x9e, 0x6f, 0x01, 0x08, 0x23, 0x0b, 0x6f, 0x30, 0x50, 0x02,
+	0x10, 0xee, 0x6f, 0x38, 0x40, 0xfe, 0x80, 0xfa, 0x48, 0x34, 0xac, 0x88, 0xac, 0xce, 0xae, 0x5c,
+	0xce, 0xc1, 0x51, 0x66, 0xb2, 0x09, 0x4b, 0x61, 0xec, 0x61, 0x3c, 0xb8, 0xba, 0x13, 0x7a, 0x68,
+	0x3a, 0x0d, 0x71, 0x3e, 0xb9, 0xb3, 0xde, 0x01, 0xc9, 0x93, 0x52, 0x93, 0xde, 0x81, 0x1a, 0xdf,
+	0x99, 0xc4, 0xd0, 0xba, 0x7a, 0x71, 0x97, 0x24, 0x4e, 0x7e, 0x87, 0xd5, 0x00, 0xa7, 0x6c, 0x50,
+	0xa2, 0xb7, 0xcc, 0xe1, 0xf3, 0x94, 0xa2, 0x75, 0x09, 0x6b, 0x2f, 0x69, 0x22, 0x76, 0x3b, 0x49,
+	0x5b, 0xce, 0x68, 0x6b, 0x4f, 0xa7, 0x5d, 0x29, 0xd2, 0xfe, 0x0f, 0x3a, 0x27, 0x2e, 0x1b, 0xbe,
+	0xcf, 0xf4, 0x37, 0x4b, 0xff, 0x18, 0x77, 0x6b, 0x0f, 0x8c, 0x72, 0xac, 0x6a, 0x7c, 0x0d, 0x74,
+	0xea, 0xc9, 0x50, 0xdd, 0xe1, 0x9f, 0xd6, 0x2e, 0xac, 0x0b, 0x6f, 0xa5, 0xd0, 0x59, 0x99, 0xb2,
+	0xe7, 0xb1, 0xdc, 0x41, 0x07, 0x93, 0x89, 0x5f, 0x5e, 0xf6, 0x54, 0x9d, 0x95, 0x87, 0x15, 0x7d,
+	0x02, 0x1b, 0x73, 0x65, 0x14, 0xa3, 0x3f, 0xa1, 0x11, 0x8b, 0x8c, 0x69, 0x43, 0xd9, 0xb8, 0x64,
+	0x25, 0x27, 0xb5, 0x5b, 0x7d, 0x35, 0x94, 0x4c, 0x3a, 0x33, 0xb6, 0x7b, 0xc5, 0xa1, 0xfc, 0x2c,
+	0x72, 0x94, 0x14, 0x9a, 0x4e, 0xe8, 0x50, 0x4d, 0xa8, 0x90, 0xe8, 0x21, 0x11, 0xea, 0x39, 0x11,
+	0xf6, 0xbe, 0x56, 0xa1, 0xc5, 0x7d, 0x2f, 0xe4, 0xdf, 0x81, 0x1c, 0x41, 0x5d, 0x8e, 0x98, 0xc8,
+	0x72, 0xa5, 0xf7, 0xd5, 0xec, 0x94, 0x70, 0x55, 0xe4, 0x00, 0xf4, 0x3e, 0x32, 0xf2, 0x93, 0xb0,
+	0x17, 0x5f, 0x4f, 0x73, 0xbd, 0x08, 0xaa, 0x88, 0x23, 0xa8, 0xcb, 0x07, 0x4a, 0x15, 0x2b, 0x3d,
+	0x8f, 0x66, 0xa7, 0x84, 0x67, 0xa1, 0xb2, 0x51, 0xb2, 0x60, 0x2c, 0x66, 0xa7, 0x84, 0x67, 0xa1,
+	0x52, 0x3d, 0x2a, 0xb4, 0xa4, 0x6f, 0xb3, 0x53, 0xc2, 0x55, 0xe8, 0x3e, 0x34, 0x67, 0xca, 0x20,
+	0x1b, 0xc2, 0x6b, 0x5e, 0x29, 0x66, 0xb6, 0x25, 0x07, 0x1a, 0x79, 0x0d, 0x6b, 0xf3, 0x6b, 0x4b,
+	0x7e, 0x11, 0x0e, 0x0b, 0x94, 0x60, 0x6e, 0x2f, 0xb0, 0x2a, 0x06, 0xa7, 0xb0, 0x5c, 0x58, 0x39,
+	0xb2, 0x99, 0xf9, 0xcf, 0x6d, 0xbb, 0x69, 0x3e, 0x64, 0x52, 0x79, 0x52, 0x62, 0xb9, 0x6d, 0xc9,
+	0x13, 0x2b, 0x6f, 0xa3, 0xb9, 0xbd, 0xc0, 0x2a, 0x13, 0x5e, 0xd5, 0xc5, 0x43, 0xfa, 0xf7, 0xb7,
+	0x01, 0x00, 0x45, 0x83, 0x9a, 0xf0, 0x6d, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -527,6 +1017,13 @@ type ItemServiceClient interface {
 	Update(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*UpdateItemResponse, error)
 	Delete(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*DeleteItemResponse, error)
 	GetAll(ctx context.Context, in *GetAllItemRequest, opts ...grpc.CallOption) (*GetAllItemResponse, error)
+	// ListItems : stream the items matching filter in order_by order as rows are read
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (ItemService_ListItemsClient, error)
+	// BatchItems : at most 1000 entries. Create and Delete write every entry
+	// in one transaction or fail without writing any.
+	BatchCreateItems(ctx context.Context, in *BatchCreateItemsRequest, opts ...grpc.CallOption) (*BatchCreateItemsResponse, error)
+	BatchGetItems(ctx context.Context, in *BatchGetItemsRequest, opts ...grpc.CallOption) (*BatchGetItemsResponse, error)
+	BatchDeleteItems(ctx context.Context, in *BatchDeleteItemsRequest, opts ...grpc.CallOption) (*BatchDeleteItemsResponse, error)
 }
 
 type itemServiceClient struct {
@@ -582,6 +1079,65 @@ func (c *itemServiceClient) GetAll(ctx context.Context, in *GetAllItemRequest, o
 	return out, nil
 }
 
+func (c *itemServiceClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (ItemService_ListItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ItemService_serviceDesc.Streams[0], "/api.ItemService/ListItems", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &itemServiceListItemsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ItemService_ListItemsClient interface {
+	Recv() (*Item, error)
+	grpc.ClientStream
+}
+
+type itemServiceListItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *itemServiceListItemsClient) Recv() (*Item, error) {
+	m := new(Item)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *itemServiceClient) BatchCreateItems(ctx context.Context, in *BatchCreateItemsRequest, opts ...grpc.CallOption) (*BatchCreateItemsResponse, error) {
+	out := new(BatchCreateItemsResponse)
+	err := c.cc.Invoke(ctx, "/api.ItemService/BatchCreateItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) BatchGetItems(ctx context.Context, in *BatchGetItemsRequest, opts ...grpc.CallOption) (*BatchGetItemsResponse, error) {
+	out := new(BatchGetItemsResponse)
+	err := c.cc.Invoke(ctx, "/api.ItemService/BatchGetItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) BatchDeleteItems(ctx context.Context, in *BatchDeleteItemsRequest, opts ...grpc.CallOption) (*BatchDeleteItemsResponse, error) {
+	out := new(BatchDeleteItemsResponse)
+	err := c.cc.Invoke(ctx, "/api.ItemService/BatchDeleteItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemServiceServer is the server API for ItemService service.
 type ItemServiceServer interface {
 	Create(context.Context, *CreateItemRequest) (*CreateItemResponse, error)
@@ -589,6 +1145,13 @@ type ItemServiceServer interface {
 	Update(context.Context, *UpdateItemRequest) (*UpdateItemResponse, error)
 	Delete(context.Context, *DeleteItemRequest) (*DeleteItemResponse, error)
 	GetAll(context.Context, *GetAllItemRequest) (*GetAllItemResponse, error)
+	// ListItems : stream the items matching filter in order_by order as rows are read
+	ListItems(*ListItemsRequest, ItemService_ListItemsServer) error
+	// BatchItems : at most 1000 entries. Create and Delete write every entry
+	// in one transaction or fail without writing any.
+	BatchCreateItems(context.Context, *BatchCreateItemsRequest) (*BatchCreateItemsResponse, error)
+	BatchGetItems(context.Context, *BatchGetItemsRequest) (*BatchGetItemsResponse, error)
+	BatchDeleteItems(context.Context, *BatchDeleteItemsRequest) (*BatchDeleteItemsResponse, error)
 }
 
 func RegisterItemServiceServer(s *grpc.Server, srv ItemServiceServer) {
@@ -685,6 +1248,81 @@ func _ItemService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemService_ListItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListItemsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ItemServiceServer).ListItems(m, &itemServiceListItemsServer{stream})
+}
+
+type ItemService_ListItemsServer interface {
+	Send(*Item) error
+	grpc.ServerStream
+}
+
+type itemServiceListItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *itemServiceListItemsServer) Send(m *Item) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ItemService_BatchCreateItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).BatchCreateItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ItemService/BatchCreateItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).BatchCreateItems(ctx, req.(*BatchCreateItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_BatchGetItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).BatchGetItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ItemService/BatchGetItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).BatchGetItems(ctx, req.(*BatchGetItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_BatchDeleteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).BatchDeleteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ItemService/BatchDeleteItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).BatchDeleteItems(ctx, req.(*BatchDeleteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ItemService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.ItemService",
 	HandlerType: (*ItemServiceServer)(nil),
@@ -709,7 +1347,25 @@ var _ItemService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAll",
 			Handler:    _ItemService_GetAll_Handler,
 		},
+		{
+			MethodName: "BatchCreateItems",
+			Handler:    _ItemService_BatchCreateItems_Handler,
+		},
+		{
+			MethodName: "BatchGetItems",
+			Handler:    _ItemService_BatchGetItems_Handler,
+		},
+		{
+			MethodName: "BatchDeleteItems",
+			Handler:    _ItemService_BatchDeleteItems_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListItems",
+			Handler:       _ItemService_ListItems_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "item-service.proto",
 }
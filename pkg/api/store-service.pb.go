@@ -398,6 +398,8 @@ func (m *DeleteStoreResponse) GetDeleted() int64 {
 }
 
 type GetAllStoreRequest struct {
+	PageSize             int32    `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -428,8 +430,23 @@ func (m *GetAllStoreRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetAllStoreRequest proto.InternalMessageInfo
 
+func (m *GetAllStoreRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllStoreRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type GetAllStoreResponse struct {
 	Stores               []*Store `protobuf:"bytes,1,rep,name=stores,proto3" json:"stores,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -467,6 +484,13 @@ func (m *GetAllStoreResponse) GetStores() []*Store {
 	return nil
 }
 
+func (m *GetAllStoreResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Store)(nil), "api.Store")
 	proto.RegisterType((*CreateStoreRequest)(nil), "api.CreateStoreRequest")
@@ -484,29 +508,32 @@ func init() {
 func init() { proto.RegisterFile("store-service.proto", fileDescriptor_750678ccd6801cc1) }
 
 var fileDescriptor_750678ccd6801cc1 = []byte{
-	// 350 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x25, 0x49, 0x9b, 0xe2, 0x28, 0x2a, 0x93, 0x8a, 0x4b, 0x4f, 0x71, 0x51, 0xe8, 0xc5, 0x16,
-	0xaa, 0x14, 0x24, 0x27, 0x51, 0xe8, 0xbd, 0xc5, 0x83, 0xc7, 0x68, 0xe6, 0x10, 0x48, 0x9b, 0x98,
-	0xdd, 0xfa, 0x69, 0x7e, 0x9f, 0x74, 0x77, 0x6a, 0x4d, 0x36, 0x20, 0xde, 0x76, 0xdf, 0xcc, 0x7b,
-	0x6f, 0xf6, 0x4d, 0x02, 0x91, 0xd2, 0x65, 0x4d, 0xb7, 0x8a, 0xea, 0xcf, 0xfc, 0x9d, 0x26, 0x55,
-	0x5d, 0xea, 0x12, 0x83, 0xb4, 0xca, 0xe5, 0x2b, 0xf4, 0x57, 0xbb, 0x1a, 0x9e, 0x82, 0x9f, 0x67,
-	0xc2, 0x8b, 0xbd, 0x71, 0xb0, 0xf4, 0xf3, 0x0c, 0x11, 0x7a, 0x9b, 0x74, 0x4d, 0xc2, 0x8f, 0xbd,
-	0xf1, 0xd1, 0xd2, 0x9c, 0x77, 0xd8, 0x3a, 0xcd, 0x0b, 0xd1, 0xb3, 0xd8, 0xee, 0x8c, 0x02, 0x06,
-	0x69, 0x96, 0xd5, 0xa4, 0x94, 0xe8, 0x1b, 0x78, 0x7f, 0x95, 0x73, 0xc0, 0xa7, 0x9a, 0x52, 0x4d,
-	0xc6, 0x60, 0x49, 0x1f, 0x5b, 0x52, 0x1a, 0x63, 0xe8, 0x9b, 0x61, 0x8c, 0xd5, 0xf1, 0x0c, 0x26,
-	0x69, 0x95, 0x4f, 0x6c, 0x87, 0x2d, 0xc8, 0x1b, 0x88, 0x1a, 0x3c, 0x55, 0x95, 0x1b, 0xe5, 0x0c,
-	0x28, 0xaf, 0xe0, 0x6c, 0x41, 0xba, 0xa1, 0xdd, 0x6e, 0xb9, 0x87, 0xf3, 0x43, 0x0b, 0xcb, 0xfc,
-	0xed, 0x3f, 0x07, 0x7c, 0xa9, 0xb2, 0xff, 0xcf, 0x3d, 0x85, 0xa8, 0xc1, 0x63, 0x43, 0x01, 0x83,
-	0xad, 0x81, 0xf7, 0x93, 0xed, 0xaf, 0xf2, 0x1a, 0xf0, 0x99, 0x0a, 0x6a, 0x19, 0xb5, 0x1f, 0x31,
-	0x85, 0xa8, 0xd1, 0x75, 0x90, 0xcd, 0x0c, 0xfc, 0x23, 0xcb, 0x57, 0x39, 0x04, 0x5c, 0x90, 0x7e,
-	0x2c, 0x8a, 0xdf, 0xb2, 0xf2, 0x01, 0xa2, 0x06, 0xca, 0x32, 0x12, 0x42, 0x33, 0xbd, 0x12, 0x5e,
-	0x1c, 0xb4, 0xde, 0xc5, 0x95, 0xd9, 0x97, 0x0f, 0x27, 0x06, 0x59, 0xd9, 0xef, 0x07, 0x13, 0x08,
-	0xed, 0x86, 0xf0, 0xd2, 0xb4, 0xbb, 0x6b, 0x1e, 0x09, 0xb7, 0xc0, 0x8e, 0x33, 0x08, 0x16, 0xa4,
-	0x71, 0x68, 0x1a, 0x5a, 0x1b, 0x1c, 0x5d, 0xb4, 0x50, 0xe6, 0x24, 0x10, 0xda, 0x68, 0xd9, 0xd0,
-	0xdd, 0x0f, 0x1b, 0x76, 0x2d, 0x20, 0x81, 0xd0, 0x06, 0xc8, 0x64, 0x37, 0x73, 0x26, 0x77, 0xc5,
-	0x9c, 0x40, 0x68, 0x63, 0x63, 0xb2, 0x9b, 0x2c, 0x93, 0x3b, 0xc2, 0x7d, 0x0b, 0xcd, 0x8f, 0x76,
-	0xf7, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x6b, 0x0b, 0x4e, 0x33, 0x7f, 0x03, 0x00, 0x00,
+	// 400 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0xab, 0xd3, 0x40,
+	0x14, 0x25, 0x49, 0x93, 0xda, 0xeb, 0x47, 0x65, 0xa2, 0x38, 0x44, 0x84, 0x38, 0xa8, 0x74, 0x63,
+	0x0b, 0x55, 0xba, 0xe9, 0x4a, 0x14, 0xba, 0x2d, 0xa9, 0x2e, 0x5c, 0x95, 0xd1, 0x5c, 0x64, 0x30,
+	0x4d, 0x62, 0x66, 0x2a, 0x8f, 0xfe, 0xb0, 0xf7, 0xfb, 0x1e, 0x99, 0x99, 0xbc, 0x90, 0x0c, 0xbc,
+	0xc7, 0xdb, 0x65, 0xce, 0xb9, 0xe7, 0xdc, 0x33, 0x73, 0x6f, 0x20, 0x96, 0xaa, 0x6a, 0xf0, 0xa3,
+	0xc4, 0xe6, 0xbf, 0xf8, 0x8d, 0xcb, 0xba, 0xa9, 0x54, 0x45, 0x02, 0x5e, 0x0b, 0xf6, 0x13, 0xc2,
+	0x43, 0xcb, 0x91, 0x67, 0xe0, 0x8b, 0x9c, 0x7a, 0xa9, 0xb7, 0x08, 0x32, 0x5f, 0xe4, 0x84, 0xc0,
+	0xa4, 0xe4, 0x27, 0xa4, 0x7e, 0xea, 0x2d, 0x66, 0x99, 0xfe, 0x6e, 0xb1, 0x13, 0x17, 0x05, 0x9d,
+	0x18, 0xac, 0xfd, 0x26, 0x14, 0xa6, 0x3c, 0xcf, 0x1b, 0x94, 0x92, 0x86, 0x1a, 0xee, 0x8e, 0x6c,
+	0x03, 0xe4, 0x6b, 0x83, 0x5c, 0xa1, 0x6e, 0x90, 0xe1, 0xbf, 0x33, 0x4a, 0x45, 0x52, 0x08, 0x75,
+	0x18, 0xdd, 0xea, 0xf1, 0x1a, 0x96, 0xbc, 0x16, 0x4b, 0x53, 0x61, 0x08, 0xf6, 0x1e, 0xe2, 0x81,
+	0x4e, 0xd6, 0x55, 0x29, 0x9d, 0x80, 0xec, 0x2d, 0xcc, 0x77, 0xa8, 0x06, 0xde, 0xe3, 0x92, 0xcf,
+	0xf0, 0xbc, 0x2f, 0xb1, 0x36, 0xf7, 0xf7, 0xdf, 0x00, 0xf9, 0x51, 0xe7, 0x0f, 0xcf, 0xbd, 0x82,
+	0x78, 0xa0, 0xb3, 0x0d, 0x29, 0x4c, 0xcf, 0x1a, 0xee, 0x92, 0x75, 0x47, 0xf6, 0x0e, 0xc8, 0x37,
+	0x2c, 0x50, 0xe1, 0x9d, 0x97, 0x58, 0x41, 0x3c, 0xa8, 0xea, 0x6d, 0x73, 0x0d, 0xdf, 0xda, 0xda,
+	0x23, 0xdb, 0x03, 0xd9, 0xa1, 0xfa, 0x52, 0x14, 0x03, 0xdb, 0xd7, 0x30, 0xab, 0xf9, 0x1f, 0x3c,
+	0x4a, 0x71, 0x31, 0x77, 0x08, 0xb3, 0x47, 0x2d, 0x70, 0x10, 0x17, 0x24, 0x6f, 0x00, 0x34, 0xa9,
+	0xaa, 0xbf, 0x58, 0xda, 0x91, 0xeb, 0xf2, 0xef, 0x2d, 0xc0, 0x38, 0xc4, 0x03, 0x47, 0x1b, 0x81,
+	0x41, 0xa4, 0x6f, 0x2e, 0xa9, 0x97, 0x06, 0xa3, 0x37, 0xb1, 0x0c, 0xf9, 0x00, 0xf3, 0x12, 0xaf,
+	0xd4, 0xd1, 0xb1, 0x7f, 0xda, 0xc2, 0xfb, 0xae, 0xc5, 0xfa, 0xda, 0x87, 0x27, 0x5a, 0x79, 0x30,
+	0x3b, 0x4a, 0xb6, 0x10, 0x99, 0x2d, 0x20, 0xaf, 0xb4, 0xad, 0xbb, 0x4a, 0x09, 0x75, 0x09, 0x9b,
+	0x6c, 0x0d, 0xc1, 0x0e, 0x15, 0x79, 0xa1, 0x0b, 0x46, 0x5b, 0x92, 0xbc, 0x1c, 0xa1, 0x56, 0xb3,
+	0x85, 0xc8, 0x8c, 0xcf, 0x36, 0x74, 0x77, 0x20, 0xa1, 0x2e, 0xd1, 0x8b, 0xcd, 0x90, 0xac, 0xd8,
+	0x9d, 0x6b, 0x42, 0x5d, 0xa2, 0x17, 0x9b, 0xe7, 0xb5, 0x62, 0x77, 0x7a, 0x09, 0x75, 0x09, 0x23,
+	0xfe, 0x15, 0xe9, 0x9f, 0xf9, 0xd3, 0xcd, 0x00, 0x19, 0x48, 0x2e, 0x9a, 0xe3, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -28,6 +30,7 @@ type User struct {
 	Age                  int64    `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
 	Mail                 string   `protobuf:"bytes,4,opt,name=mail,proto3" json:"mail,omitempty"`
 	Address              string   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Version              int64    `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -93,6 +96,13 @@ func (m *User) GetAddress() string {
 	return ""
 }
 
+func (m *User) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type CreateUserRequest struct {
 	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -250,10 +260,15 @@ func (m *GetUserResponse) GetUser() *User {
 }
 
 type UpdateUserRequest struct {
-	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// user.version, when not 0, must be the stored version or the update
+	// fails with ABORTED
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// Fields of user to write, one or more of name, age, mail, address.
+	// Every field is written when unset or "*".
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *UpdateUserRequest) Reset()         { *m = UpdateUserRequest{} }
@@ -288,6 +303,13 @@ func (m *UpdateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (m *UpdateUserRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type UpdateUserResponse struct {
 	Updated              int64    `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -329,6 +351,7 @@ func (m *UpdateUserResponse) GetUpdated() int64 {
 
 type DeleteUserRequest struct {
 	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -366,6 +389,13 @@ func (m *DeleteUserRequest) GetId() int64 {
 	return 0
 }
 
+func (m *DeleteUserRequest) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type DeleteUserResponse struct {
 	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -405,17 +435,85 @@ func (m *DeleteUserResponse) GetDeleted() int64 {
 	return 0
 }
 
+// UserFilter : conditions of a user listing, unset fields match every user
+type UserFilter struct {
+	NamePrefix           string               `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	MinAge               *wrappers.Int64Value `protobuf:"bytes,2,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxAge               *wrappers.Int64Value `protobuf:"bytes,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	MailDomain           string               `protobuf:"bytes,4,opt,name=mail_domain,json=mailDomain,proto3" json:"mail_domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UserFilter) Reset()         { *m = UserFilter{} }
+func (m *UserFilter) String() string { return proto.CompactTextString(m) }
+func (*UserFilter) ProtoMessage()    {}
+func (*UserFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{9}
+}
+
+func (m *UserFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserFilter.Unmarshal(m, b)
+}
+func (m *UserFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserFilter.Marshal(b, m, deterministic)
+}
+func (m *UserFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserFilter.Merge(m, src)
+}
+func (m *UserFilter) XXX_Size() int {
+	return xxx_messageInfo_UserFilter.Size(m)
+}
+func (m *UserFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserFilter proto.InternalMessageInfo
+
+func (m *UserFilter) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+func (m *UserFilter) GetMinAge() *wrappers.Int64Value {
+	if m != nil {
+		return m.MinAge
+	}
+	return nil
+}
+
+func (m *UserFilter) GetMaxAge() *wrappers.Int64Value {
+	if m != nil {
+		return m.MaxAge
+	}
+	return nil
+}
+
+func (m *UserFilter) GetMailDomain() string {
+	if m != nil {
+		return m.MailDomain
+	}
+	return ""
+}
+
 type GetAllUserRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageSize             int32       `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter               *UserFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string      `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *GetAllUserRequest) Reset()         { *m = GetAllUserRequest{} }
 func (m *GetAllUserRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllUserRequest) ProtoMessage()    {}
 func (*GetAllUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2a3086c73a75cdba, []int{9}
+	return fileDescriptor_2a3086c73a75cdba, []int{10}
 }
 
 func (m *GetAllUserRequest) XXX_Unmarshal(b []byte) error {
@@ -436,8 +534,37 @@ func (m *GetAllUserRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetAllUserRequest proto.InternalMessageInfo
 
+func (m *GetAllUserRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *GetAllUserRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *GetAllUserRequest) GetFilter() *UserFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *GetAllUserRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
 type GetAllUserResponse struct {
 	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -447,7 +574,7 @@ func (m *GetAllUserResponse) Reset()         { *m = GetAllUserResponse{} }
 func (m *GetAllUserResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAllUserResponse) ProtoMessage()    {}
 func (*GetAllUserResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2a3086c73a75cdba, []int{10}
+	return fileDescriptor_2a3086c73a75cdba, []int{11}
 }
 
 func (m *GetAllUserResponse) XXX_Unmarshal(b []byte) error {
@@ -475,6 +602,341 @@ func (m *GetAllUserResponse) GetUsers() []*User {
 	return nil
 }
 
+func (m *GetAllUserResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	Filter               *UserFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy              string      `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListUsersRequest) Reset()         { *m = ListUsersRequest{} }
+func (m *ListUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUsersRequest) ProtoMessage()    {}
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{12}
+}
+
+func (m *ListUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersRequest.Unmarshal(m, b)
+}
+func (m *ListUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersRequest.Merge(m, src)
+}
+func (m *ListUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUsersRequest.Size(m)
+}
+func (m *ListUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersRequest proto.InternalMessageInfo
+
+func (m *ListUsersRequest) GetFilter() *UserFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListUsersRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+type BatchCreateUsersRequest struct {
+	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchCreateUsersRequest) Reset()         { *m = BatchCreateUsersRequest{} }
+func (m *BatchCreateUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateUsersRequest) ProtoMessage()    {}
+func (*BatchCreateUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{13}
+}
+
+func (m *BatchCreateUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateUsersRequest.Unmarshal(m, b)
+}
+func (m *BatchCreateUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateUsersRequest.Merge(m, src)
+}
+func (m *BatchCreateUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateUsersRequest.Size(m)
+}
+func (m *BatchCreateUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateUsersRequest proto.InternalMessageInfo
+
+func (m *BatchCreateUsersRequest) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+type BatchCreateUsersResponse struct {
+	Ids                  []int64  `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchCreateUsersResponse) Reset()         { *m = BatchCreateUsersResponse{} }
+func (m *BatchCreateUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateUsersResponse) ProtoMessage()    {}
+func (*BatchCreateUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{14}
+}
+
+func (m *BatchCreateUsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateUsersResponse.Unmarshal(m, b)
+}
+func (m *BatchCreateUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateUsersResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchCreateUsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateUsersResponse.Merge(m, src)
+}
+func (m *BatchCreateUsersResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateUsersResponse.Size(m)
+}
+func (m *BatchCreateUsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateUsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchCreateUsersResponse proto.InternalMessageInfo
+
+func (m *BatchCreateUsersResponse) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type BatchGetUsersRequest struct {
+	Ids                  []int64  `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetUsersRequest) Reset()         { *m = BatchGetUsersRequest{} }
+func (m *BatchGetUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetUsersRequest) ProtoMessage()    {}
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{15}
+}
+
+func (m *BatchGetUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetUsersRequest.Unmarshal(m, b)
+}
+func (m *BatchGetUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchGetUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetUsersRequest.Merge(m, src)
+}
+func (m *BatchGetUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchGetUsersRequest.Size(m)
+}
+func (m *BatchGetUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetUsersRequest proto.InternalMessageInfo
+
+func (m *BatchGetUsersRequest) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type UserResult struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	User                 *User    `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserResult) Reset()         { *m = UserResult{} }
+func (m *UserResult) String() string { return proto.CompactTextString(m) }
+func (*UserResult) ProtoMessage()    {}
+func (*UserResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{16}
+}
+
+func (m *UserResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserResult.Unmarshal(m, b)
+}
+func (m *UserResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserResult.Marshal(b, m, deterministic)
+}
+func (m *UserResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserResult.Merge(m, src)
+}
+func (m *UserResult) XXX_Size() int {
+	return xxx_messageInfo_UserResult.Size(m)
+}
+func (m *UserResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserResult proto.InternalMessageInfo
+
+func (m *UserResult) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UserResult) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type BatchGetUsersResponse struct {
+	Results              []*UserResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BatchGetUsersResponse) Reset()         { *m = BatchGetUsersResponse{} }
+func (m *BatchGetUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetUsersResponse) ProtoMessage()    {}
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{17}
+}
+
+func (m *BatchGetUsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetUsersResponse.Unmarshal(m, b)
+}
+func (m *BatchGetUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetUsersResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchGetUsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetUsersResponse.Merge(m, src)
+}
+func (m *BatchGetUsersResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchGetUsersResponse.Size(m)
+}
+func (m *BatchGetUsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetUsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetUsersResponse proto.InternalMessageInfo
+
+func (m *BatchGetUsersResponse) GetResults() []*UserResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchDeleteUsersRequest struct {
+	Users                []*DeleteUserRequest `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BatchDeleteUsersRequest) Reset()         { *m = BatchDeleteUsersRequest{} }
+func (m *BatchDeleteUsersRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteUsersRequest) ProtoMessage()    {}
+func (*BatchDeleteUsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{18}
+}
+
+func (m *BatchDeleteUsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteUsersRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteUsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteUsersRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteUsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteUsersRequest.Merge(m, src)
+}
+func (m *BatchDeleteUsersRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteUsersRequest.Size(m)
+}
+func (m *BatchDeleteUsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteUsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteUsersRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteUsersRequest) GetUsers() []*DeleteUserRequest {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+type BatchDeleteUsersResponse struct {
+	Deleted              []int64  `protobuf:"varint,1,rep,packed,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteUsersResponse) Reset()         { *m = BatchDeleteUsersResponse{} }
+func (m *BatchDeleteUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteUsersResponse) ProtoMessage()    {}
+func (*BatchDeleteUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2a3086c73a75cdba, []int{19}
+}
+
+func (m *BatchDeleteUsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteUsersResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteUsersResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteUsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteUsersResponse.Merge(m, src)
+}
+func (m *BatchDeleteUsersResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteUsersResponse.Size(m)
+}
+func (m *BatchDeleteUsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteUsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteUsersResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteUsersResponse) GetDeleted() []int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*User)(nil), "api.User")
 	proto.RegisterType((*CreateUserRequest)(nil), "api.CreateUserRequest")
@@ -485,37 +947,74 @@ func init() {
 	proto.RegisterType((*UpdateUserResponse)(nil), "api.UpdateUserResponse")
 	proto.RegisterType((*DeleteUserRequest)(nil), "api.DeleteUserRequest")
 	proto.RegisterType((*DeleteUserResponse)(nil), "api.DeleteUserResponse")
+	proto.RegisterType((*UserFilter)(nil), "api.UserFilter")
 	proto.RegisterType((*GetAllUserRequest)(nil), "api.GetAllUserRequest")
 	proto.RegisterType((*GetAllUserResponse)(nil), "api.GetAllUserResponse")
+	proto.RegisterType((*ListUsersRequest)(nil), "api.ListUsersRequest")
+	proto.RegisterType((*BatchCreateUsersRequest)(nil), "api.BatchCreateUsersRequest")
+	proto.RegisterType((*BatchCreateUsersResponse)(nil), "api.BatchCreateUsersResponse")
+	proto.RegisterType((*BatchGetUsersRequest)(nil), "api.BatchGetUsersRequest")
+	proto.RegisterType((*UserResult)(nil), "api.UserResult")
+	proto.RegisterType((*BatchGetUsersResponse)(nil), "api.BatchGetUsersResponse")
+	proto.RegisterType((*BatchDeleteUsersRequest)(nil), "api.BatchDeleteUsersRequest")
+	proto.RegisterType((*BatchDeleteUsersResponse)(nil), "api.BatchDeleteUsersResponse")
 }
 
 func init() { proto.RegisterFile("user-service.proto", fileDescriptor_2a3086c73a75cdba) }
 
 var fileDescriptor_2a3086c73a75cdba = []byte{
-	// 361 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xcd, 0x4a, 0xf3, 0x50,
-	0x10, 0x25, 0x3f, 0x4d, 0xe9, 0x14, 0xfa, 0x7d, 0x99, 0x8a, 0x5e, 0x0a, 0x62, 0xb8, 0xba, 0xe8,
-	0xc6, 0x50, 0x22, 0x2e, 0xb2, 0x14, 0x85, 0xee, 0x2b, 0x7d, 0x80, 0xab, 0x19, 0x24, 0x92, 0xb6,
-	0x31, 0x37, 0xf5, 0xa5, 0x7c, 0x49, 0xb9, 0x3f, 0xad, 0xf9, 0x29, 0x88, 0xbb, 0x99, 0x73, 0xcf,
-	0x99, 0x33, 0x3d, 0xd3, 0x00, 0xee, 0x25, 0x55, 0xb7, 0x92, 0xaa, 0xcf, 0xfc, 0x95, 0xe2, 0xb2,
-	0xda, 0xd5, 0x3b, 0xf4, 0x44, 0x99, 0xf3, 0x77, 0xf0, 0xd7, 0x92, 0x2a, 0x9c, 0x80, 0x9b, 0x67,
-	0xcc, 0x89, 0x9c, 0xb9, 0xb7, 0x72, 0xf3, 0x0c, 0x11, 0xfc, 0xad, 0xd8, 0x10, 0x73, 0x23, 0x67,
-	0x3e, 0x5a, 0xe9, 0x1a, 0xff, 0x83, 0x27, 0xde, 0x88, 0x79, 0x9a, 0xa4, 0x4a, 0xc5, 0xda, 0x88,
-	0xbc, 0x60, 0xbe, 0x61, 0xa9, 0x1a, 0x19, 0x0c, 0x45, 0x96, 0x55, 0x24, 0x25, 0x1b, 0x68, 0xf8,
-	0xd0, 0xf2, 0x04, 0xc2, 0xc7, 0x8a, 0x44, 0x4d, 0xca, 0x71, 0x45, 0x1f, 0x7b, 0x92, 0x35, 0x5e,
-	0x82, 0xaf, 0x76, 0xd3, 0xd6, 0xe3, 0x64, 0x14, 0x8b, 0x32, 0x8f, 0xf5, 0xbb, 0x86, 0xf9, 0x0d,
-	0x60, 0x53, 0x23, 0xcb, 0xdd, 0x56, 0x52, 0x77, 0x5b, 0x1e, 0xc1, 0x64, 0x49, 0x75, 0x73, 0x6c,
-	0x97, 0xb1, 0x80, 0x7f, 0x47, 0x86, 0x1d, 0xf2, 0x8b, 0x73, 0x02, 0xe1, 0xba, 0xcc, 0xfe, 0xb6,
-	0x6d, 0x0c, 0xd8, 0xd4, 0x58, 0x23, 0x06, 0xc3, 0xbd, 0x46, 0x0f, 0x0b, 0x1d, 0x5a, 0x7e, 0x0d,
-	0xe1, 0x13, 0x15, 0xd4, 0xf6, 0xe8, 0xae, 0x1e, 0x03, 0x36, 0x49, 0x3f, 0x43, 0x33, 0x8d, 0x1e,
-	0x87, 0xda, 0x96, 0x4f, 0x21, 0x5c, 0x52, 0xfd, 0x50, 0x14, 0x8d, 0xa1, 0xfc, 0x1e, 0xb0, 0x09,
-	0xda, 0x21, 0x57, 0x30, 0x50, 0x7b, 0x4b, 0xe6, 0x44, 0x5e, 0xfb, 0xf7, 0x18, 0x3c, 0xf9, 0x72,
-	0x61, 0xac, 0xfa, 0x67, 0xf3, 0xcf, 0xc1, 0x14, 0x02, 0x73, 0x0e, 0x3c, 0xd7, 0xdc, 0xde, 0x3d,
-	0x67, 0x17, 0x3d, 0xdc, 0x7a, 0x2d, 0xc0, 0x5b, 0x52, 0x8d, 0x53, 0xfd, 0xde, 0xbe, 0xd6, 0xec,
-	0xac, 0x0d, 0x5a, 0x45, 0x0a, 0x81, 0x49, 0xd3, 0x9a, 0xf5, 0xce, 0x61, 0xcd, 0x4e, 0x44, 0x9e,
-	0x42, 0x60, 0x32, 0xb3, 0xd2, 0x5e, 0xca, 0x56, 0x7a, 0x22, 0xd8, 0x14, 0x02, 0x93, 0x94, 0x95,
-	0xf6, 0xb2, 0xb4, 0xd2, 0x7e, 0x9c, 0x2f, 0x81, 0xfe, 0xb0, 0xee, 0xbe, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0xe7, 0xc9, 0x3b, 0x0a, 0x6e, 0x03, 0x00, 0x00,
+	// 814 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdd, 0x6e, 0xeb, 0x44,
+	0x10, 0x96, 0xe3, 0x34, 0x39, 0x99, 0xe8, 0x9c, 0x26, 0xcb, 0x29, 0x71, 0x5d, 0x4a, 0x23, 0x0b,
+	0x41, 0x91, 0x8a, 0x5b, 0x85, 0x0a, 0xa9, 0x54, 0x5c, 0xb4, 0x54, 0x8d, 0x90, 0x40, 0x54, 0x2e,
+	0xed, 0x1d, 0xb2, 0xb6, 0xf5, 0x24, 0xac, 0xea, 0xd8, 0xc6, 0xeb, 0x94, 0xb4, 0xd7, 0xbc, 0x01,
+	0x6f, 0xc3, 0x03, 0xf0, 0x5c, 0x68, 0x7f, 0x1c, 0xdb, 0x71, 0x43, 0x39, 0x77, 0xde, 0x99, 0x6f,
+	0x66, 0xbe, 0x99, 0xfd, 0x66, 0x0d, 0x64, 0xce, 0x31, 0xfd, 0x8a, 0x63, 0xfa, 0xc8, 0xee, 0xd1,
+	0x4d, 0xd2, 0x38, 0x8b, 0x89, 0x49, 0x13, 0x66, 0x0f, 0xa7, 0x71, 0x3c, 0x0d, 0xf1, 0x50, 0x9a,
+	0xee, 0xe6, 0x93, 0xc3, 0x09, 0xc3, 0x30, 0xf0, 0x67, 0x94, 0x3f, 0x28, 0x98, 0xfd, 0xe9, 0x2a,
+	0xe2, 0x8f, 0x94, 0x26, 0x09, 0xa6, 0x5c, 0xf9, 0x9d, 0x3f, 0x0d, 0x68, 0xde, 0x70, 0x4c, 0xc9,
+	0x3b, 0x68, 0xb0, 0xc0, 0x32, 0x86, 0xc6, 0xbe, 0xe9, 0x35, 0x58, 0x40, 0x08, 0x34, 0x23, 0x3a,
+	0x43, 0xab, 0x31, 0x34, 0xf6, 0x3b, 0x9e, 0xfc, 0x26, 0x3d, 0x30, 0xe9, 0x14, 0x2d, 0x53, 0x82,
+	0xc4, 0xa7, 0x40, 0xcd, 0x28, 0x0b, 0xad, 0xa6, 0x42, 0x89, 0x6f, 0x62, 0x41, 0x9b, 0x06, 0x41,
+	0x8a, 0x9c, 0x5b, 0x1b, 0xd2, 0x9c, 0x1f, 0x85, 0xe7, 0x11, 0x53, 0xce, 0xe2, 0xc8, 0x6a, 0xc9,
+	0x1c, 0xf9, 0xd1, 0x19, 0x41, 0xff, 0xfb, 0x14, 0x69, 0x86, 0x82, 0x8b, 0x87, 0xbf, 0xcf, 0x91,
+	0x67, 0x64, 0x17, 0x9a, 0xa2, 0x71, 0x49, 0xaa, 0x3b, 0xea, 0xb8, 0x34, 0x61, 0xae, 0xf4, 0x4b,
+	0xb3, 0xf3, 0x19, 0x90, 0x72, 0x0c, 0x4f, 0xe2, 0x88, 0xe3, 0x6a, 0x1f, 0xce, 0x10, 0xde, 0x8d,
+	0x31, 0x2b, 0xa7, 0x5d, 0x45, 0x1c, 0xc1, 0xe6, 0x12, 0xa1, 0x93, 0xbc, 0x52, 0x39, 0x86, 0xfe,
+	0x4d, 0x12, 0x7c, 0x10, 0x5b, 0x72, 0x0a, 0xdd, 0xb9, 0x8c, 0x91, 0xb7, 0x23, 0xc7, 0xda, 0x1d,
+	0xd9, 0xae, 0xba, 0x1e, 0x37, 0xbf, 0x1e, 0xf7, 0x52, 0x5c, 0xe0, 0x4f, 0x94, 0x3f, 0x78, 0xa0,
+	0xe0, 0xe2, 0xdb, 0x71, 0x81, 0x94, 0x0b, 0x6a, 0x96, 0x16, 0xb4, 0x15, 0x26, 0xef, 0x26, 0x3f,
+	0x3a, 0xdf, 0x41, 0xff, 0x02, 0x43, 0xcc, 0xf0, 0x3f, 0xfa, 0x2e, 0xdf, 0x46, 0xa3, 0x7a, 0x1b,
+	0x2e, 0x90, 0x72, 0x78, 0x51, 0x2e, 0x90, 0xd6, 0x65, 0x39, 0x7d, 0x74, 0xfe, 0x36, 0x00, 0x04,
+	0xf4, 0x92, 0x85, 0x19, 0xa6, 0x64, 0x0f, 0xba, 0x42, 0x2e, 0x7e, 0x92, 0xe2, 0x84, 0x2d, 0x24,
+	0xb8, 0xe3, 0x81, 0x30, 0x5d, 0x49, 0x0b, 0x39, 0x86, 0xf6, 0x8c, 0x45, 0xbe, 0xd0, 0x92, 0x9a,
+	0xc3, 0x4e, 0x6d, 0x0e, 0x3f, 0x44, 0xd9, 0x37, 0xc7, 0xb7, 0x34, 0x9c, 0xa3, 0xd7, 0x9a, 0xb1,
+	0xe8, 0x6c, 0x8a, 0x32, 0x8a, 0x2e, 0xfc, 0x5c, 0x81, 0xaf, 0x46, 0xd1, 0x85, 0x88, 0xda, 0x83,
+	0xae, 0x50, 0xa5, 0x1f, 0xc4, 0x33, 0xca, 0x22, 0x2d, 0x54, 0x10, 0xa6, 0x0b, 0x69, 0x71, 0xfe,
+	0x32, 0xa0, 0x3f, 0xc6, 0xec, 0x2c, 0x0c, 0xcb, 0xc3, 0xda, 0x81, 0x4e, 0x42, 0xa7, 0xe8, 0x73,
+	0xf6, 0x8c, 0xb2, 0x83, 0x0d, 0xef, 0x8d, 0x30, 0x5c, 0xb3, 0x67, 0x21, 0x0f, 0x90, 0xce, 0x2c,
+	0x7e, 0xc0, 0x48, 0x6f, 0x88, 0x84, 0xff, 0x22, 0x0c, 0xe4, 0x0b, 0x68, 0x4d, 0xe4, 0x24, 0x34,
+	0xcf, 0xcd, 0xa5, 0x16, 0xd4, 0x80, 0x3c, 0xed, 0x26, 0xdb, 0xf0, 0x26, 0x4e, 0x03, 0x4c, 0xfd,
+	0xbb, 0x27, 0x4d, 0xac, 0x2d, 0xcf, 0xe7, 0x4f, 0xce, 0xaf, 0x40, 0xca, 0xa4, 0xf4, 0x15, 0xec,
+	0xc1, 0x86, 0x10, 0x13, 0xb7, 0x8c, 0xa1, 0x59, 0x15, 0x99, 0xb2, 0x93, 0xcf, 0x61, 0x33, 0xc2,
+	0x45, 0xe6, 0xd7, 0xe8, 0xbd, 0x15, 0xe6, 0xab, 0x9c, 0xa2, 0x73, 0x0b, 0xbd, 0x1f, 0x19, 0x97,
+	0xa2, 0xe7, 0x79, 0xcb, 0x05, 0x6d, 0xe3, 0xff, 0xd3, 0x6e, 0x54, 0x69, 0x7f, 0x0b, 0x83, 0x73,
+	0x9a, 0xdd, 0xff, 0x56, 0x2c, 0xe6, 0x32, 0xfd, 0x6b, 0xdc, 0x9d, 0x03, 0xb0, 0xea, 0xb1, 0xba,
+	0xf1, 0x1e, 0x98, 0x2c, 0x50, 0xa1, 0xa6, 0x27, 0x3e, 0x9d, 0x7d, 0x78, 0x2f, 0xd1, 0x7a, 0x75,
+	0x97, 0x65, 0xea, 0xc8, 0x53, 0x25, 0x4e, 0x0f, 0xf9, 0x3c, 0xac, 0x6f, 0x41, 0xbe, 0xb6, 0x8d,
+	0x97, 0x57, 0xfd, 0x1c, 0xb6, 0x56, 0xca, 0x68, 0x46, 0x5f, 0x42, 0x3b, 0x95, 0x19, 0xf3, 0x86,
+	0x8a, 0x71, 0xa9, 0x4a, 0x5e, 0xee, 0x77, 0xc6, 0x7a, 0x28, 0xc5, 0x4e, 0x2d, 0xd9, 0x1e, 0x54,
+	0x87, 0xf2, 0xb1, 0xcc, 0x51, 0x5b, 0xdd, 0x7c, 0x42, 0xc7, 0x7a, 0x42, 0x95, 0x44, 0x2f, 0x6d,
+	0xa7, 0x59, 0xda, 0xce, 0xd1, 0x3f, 0x4d, 0xe8, 0x0a, 0xec, 0xb5, 0xfa, 0x7f, 0x90, 0x13, 0x68,
+	0xa9, 0x11, 0x13, 0x55, 0xae, 0xf6, 0xf0, 0xda, 0x83, 0x9a, 0x5d, 0x17, 0x39, 0x02, 0x73, 0x8c,
+	0x19, 0xf9, 0x48, 0xfa, 0xab, 0xcf, 0xaa, 0xfd, 0xbe, 0x6a, 0xd4, 0x11, 0x27, 0xd0, 0x52, 0x2f,
+	0x97, 0x2e, 0x56, 0x7b, 0x37, 0xed, 0x41, 0xcd, 0x5e, 0x84, 0xaa, 0x46, 0xc9, 0x9a, 0xb1, 0xd8,
+	0x83, 0x9a, 0xbd, 0x08, 0x55, 0xdb, 0xa3, 0x43, 0x6b, 0xfb, 0x6d, 0x0f, 0x6a, 0x76, 0x1d, 0x7a,
+	0x08, 0x9d, 0xe5, 0x66, 0x90, 0x2d, 0x89, 0x5a, 0xdd, 0x14, 0xbb, 0x50, 0xc9, 0x91, 0x41, 0x7e,
+	0x86, 0xde, 0xaa, 0x6c, 0xc9, 0x27, 0x12, 0xb0, 0x66, 0x13, 0xec, 0xdd, 0x35, 0x5e, 0xcd, 0xe0,
+	0x12, 0xde, 0x56, 0x24, 0x47, 0xb6, 0x0b, 0xfc, 0x8a, 0xda, 0x6d, 0xfb, 0x25, 0x97, 0xce, 0x93,
+	0x13, 0x2b, 0xa9, 0xa5, 0x4c, 0xac, 0xae, 0x46, 0x7b, 0x77, 0x8d, 0x57, 0x25, 0xbc, 0x6b, 0xc9,
+	0x77, 0xf6, 0xeb, 0x7f, 0x07, 0x00, 0x1e, 0xe6, 0x91, 0xdd, 0x8f, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -535,6 +1034,13 @@ type UserServiceClient interface {
 	Update(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	Delete(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
 	GetAll(ctx context.Context, in *GetAllUserRequest, opts ...grpc.CallOption) (*GetAllUserResponse, error)
+	// ListUsers : stream the users matching filter in order_by order as rows are read
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error)
+	// BatchUsers : at most 1000 entries. Create and Delete write every entry
+	// in one transaction or fail without writing any.
+	BatchCreateUsers(ctx context.Context, in *BatchCreateUsersRequest, opts ...grpc.CallOption) (*BatchCreateUsersResponse, error)
+	BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error)
+	BatchDeleteUsers(ctx context.Context, in *BatchDeleteUsersRequest, opts ...grpc.CallOption) (*BatchDeleteUsersResponse, error)
 }
 
 type userServiceClient struct {
@@ -590,6 +1096,65 @@ func (c *userServiceClient) GetAll(ctx context.Context, in *GetAllUserRequest, o
 	return out, nil
 }
 
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserService_serviceDesc.Streams[0], "/api.UserService/ListUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceListUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ListUsersClient interface {
+	Recv() (*User, error)
+	grpc.ClientStream
+}
+
+type userServiceListUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceListUsersClient) Recv() (*User, error) {
+	m := new(User)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) BatchCreateUsers(ctx context.Context, in *BatchCreateUsersRequest, opts ...grpc.CallOption) (*BatchCreateUsersResponse, error) {
+	out := new(BatchCreateUsersResponse)
+	err := c.cc.Invoke(ctx, "/api.UserService/BatchCreateUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchGetUsers(ctx context.Context, in *BatchGetUsersRequest, opts ...grpc.CallOption) (*BatchGetUsersResponse, error) {
+	out := new(BatchGetUsersResponse)
+	err := c.cc.Invoke(ctx, "/api.UserService/BatchGetUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchDeleteUsers(ctx context.Context, in *BatchDeleteUsersRequest, opts ...grpc.CallOption) (*BatchDeleteUsersResponse, error) {
+	out := new(BatchDeleteUsersResponse)
+	err := c.cc.Invoke(ctx, "/api.UserService/BatchDeleteUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 type UserServiceServer interface {
 	Create(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
@@ -597,6 +1162,13 @@ type UserServiceServer interface {
 	Update(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	Delete(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 	GetAll(context.Context, *GetAllUserRequest) (*GetAllUserResponse, error)
+	// ListUsers : stream the users matching filter in order_by order as rows are read
+	ListUsers(*ListUsersRequest, UserService_ListUsersServer) error
+	// BatchUsers : at most 1000 entries. Create and Delete write every entry
+	// in one transaction or fail without writing any.
+	BatchCreateUsers(context.Context, *BatchCreateUsersRequest) (*BatchCreateUsersResponse, error)
+	BatchGetUsers(context.Context, *BatchGetUsersRequest) (*BatchGetUsersResponse, error)
+	BatchDeleteUsers(context.Context, *BatchDeleteUsersRequest) (*BatchDeleteUsersResponse, error)
 }
 
 func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
@@ -693,6 +1265,81 @@ func _UserService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ListUsers(m, &userServiceListUsersServer{stream})
+}
+
+type UserService_ListUsersServer interface {
+	Send(*User) error
+	grpc.ServerStream
+}
+
+type userServiceListUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceListUsersServer) Send(m *User) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_BatchCreateUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchCreateUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.UserService/BatchCreateUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchCreateUsers(ctx, req.(*BatchCreateUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchGetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.UserService/BatchGetUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetUsers(ctx, req.(*BatchGetUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchDeleteUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchDeleteUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.UserService/BatchDeleteUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchDeleteUsers(ctx, req.(*BatchDeleteUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UserService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.UserService",
 	HandlerType: (*UserServiceServer)(nil),
@@ -717,7 +1364,25 @@ var _UserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAll",
 			Handler:    _UserService_GetAll_Handler,
 		},
+		{
+			MethodName: "BatchCreateUsers",
+			Handler:    _UserService_BatchCreateUsers_Handler,
+		},
+		{
+			MethodName: "BatchGetUsers",
+			Handler:    _UserService_BatchGetUsers_Handler,
+		},
+		{
+			MethodName: "BatchDeleteUsers",
+			Handler:    _UserService_BatchDeleteUsers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUsers",
+			Handler:       _UserService_ListUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user-service.proto",
 }
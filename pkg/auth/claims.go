@@ -0,0 +1,84 @@
+// Package auth verifies the JWT bearer tokens of RPCs and hands their
+// claims to handlers through the context.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Claims : the registered claims of a verified token and the ones
+// authorization is based on
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+	Roles     []string `json:"roles"`
+	// Scope is the space-separated list of OAuth 2.0 scopes
+	Scope string `json:"scope"`
+	// Peer is the client certificate of the connection, if any
+	Peer *Identity `json:"-"`
+}
+
+// AdminRole : role of callers allowed to act on every resource
+const AdminRole = "admin"
+
+// HasRole : whether role is one of Roles
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Scopes : the scopes listed in Scope
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// Audience : the aud claim, which is either one string or an array
+type Audience []string
+
+// UnmarshalJSON : accept both forms of aud
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*a = Audience(ss)
+	return nil
+}
+
+// Contains : whether aud lists audience
+func (a Audience) Contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+type claimsKey struct{}
+
+// NewContext : ctx carrying claims
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext : the claims NewContext put in ctx
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// jwk : the members of a JSON Web Key used for RSA and EC public keys
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// LoadJWKS : the public keys of the JWK Set in the file at path by kid.
+// Keys whose use is not "sig" and key types other than RSA and EC are
+// skipped.
+func LoadJWKS(path string) (map[string]crypto.PublicKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read JWKS: %w", err)
+	}
+	return ParseJWKS(b)
+}
+
+// ParseJWKS : LoadJWKS for the content of a JWKS file
+func ParseJWKS(b []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, xerrors.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsa()
+		case "EC":
+			key, err = k.ecdsa()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("keys[%d]: %w", i, err)
+		}
+		if _, ok := keys[k.Kid]; ok {
+			return nil, xerrors.Errorf("keys[%d]: kid %q is duplicated", i, k.Kid)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := decodeInt(k.N)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid n: %w", err)
+	}
+	e, err := decodeInt(k.E)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid e: %w", err)
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, xerrors.New("invalid e")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecdsa() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, xerrors.Errorf("curve %q is not supported", k.Crv)
+	}
+	x, err := decodeInt(k.X)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid x: %w", err)
+	}
+	y, err := decodeInt(k.Y)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid y: %w", err)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, xerrors.New("point is not on the curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, xerrors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
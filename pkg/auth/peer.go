@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Identity : who the client certificate of a mutual TLS connection was
+// issued to
+type Identity struct {
+	CommonName string
+	DNSNames   []string
+	URIs       []string
+}
+
+// Names : every name of the identity, URI SANs first, then DNS SANs and
+// the common name
+func (id *Identity) Names() []string {
+	names := append([]string{}, id.URIs...)
+	names = append(names, id.DNSNames...)
+	if id.CommonName != "" {
+		names = append(names, id.CommonName)
+	}
+	return names
+}
+
+// PeerIdentity : the identity of the verified client certificate of the
+// connection of ctx. There is none over plain TCP or when the client sent
+// no certificate.
+func PeerIdentity(ctx context.Context) (*Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	leaf := info.State.VerifiedChains[0][0]
+	id := &Identity{CommonName: leaf.Subject.CommonName, DNSNames: leaf.DNSNames}
+	for _, u := range leaf.URIs {
+		id.URIs = append(id.URIs, u.String())
+	}
+	if len(id.Names()) == 0 {
+		return nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	ctxzap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AnyRole : role of a rule which every authenticated caller has
+const AnyRole = "*"
+
+// Rule : callers having one of Roles or one of Scopes, or connected with a
+// client certificate issued to one of Peers, may call Methods.
+// A method is a full method name like "/api.UserService/Delete",
+// "/api.UserService/*" for every method of a service or "*" for every
+// method of the server.
+type Rule struct {
+	Methods []string `json:"methods"`
+	Roles   []string `json:"roles"`
+	Scopes  []string `json:"scopes"`
+	Peers   []string `json:"peers"`
+}
+
+// Policy : which callers may call which methods. For a call the rule of
+// the most specific matching method applies; calls no rule matches are
+// denied.
+type Policy struct {
+	rules map[string]*Rule
+}
+
+// LoadPolicy : the policy of the JSON file at path, which holds the rules
+// as {"rules": [{"methods": [...], "roles": [...], "scopes": [...], "peers": [...]}]}
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read policy: %w", err)
+	}
+	return ParsePolicy(b)
+}
+
+// ParsePolicy : LoadPolicy for the content of a policy file
+func ParsePolicy(b []byte) (*Policy, error) {
+	var file struct {
+		Rules []*Rule `json:"rules"`
+	}
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, xerrors.Errorf("failed to parse policy: %w", err)
+	}
+
+	p := &Policy{rules: map[string]*Rule{}}
+	for i, r := range file.Rules {
+		if len(r.Roles) == 0 && len(r.Scopes) == 0 && len(r.Peers) == 0 {
+			return nil, xerrors.Errorf("rules[%d] allows nobody", i)
+		}
+		for _, m := range r.Methods {
+			if !validMethod(m) {
+				return nil, xerrors.Errorf("rules[%d]: method %q is not a full method name", i, m)
+			}
+			if _, ok := p.rules[m]; ok {
+				return nil, xerrors.Errorf("rules[%d]: method %q has another rule", i, m)
+			}
+			p.rules[m] = r
+		}
+	}
+	return p, nil
+}
+
+// validMethod : m is "*", "/service/*" or "/service/method"
+func validMethod(m string) bool {
+	if m == "*" {
+		return true
+	}
+	parts := strings.Split(m, "/")
+	return len(parts) == 3 && parts[0] == "" && parts[1] != "" && parts[2] != ""
+}
+
+// rule : the rule for method, nil when there is none
+func (p *Policy) rule(method string) (string, *Rule) {
+	if r, ok := p.rules[method]; ok {
+		return method, r
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		service := method[:i] + "/*"
+		if r, ok := p.rules[service]; ok {
+			return service, r
+		}
+	}
+	if r, ok := p.rules["*"]; ok {
+		return "*", r
+	}
+	return "", nil
+}
+
+// Authorize : nil when claims allow to call method, a PermissionDenied
+// status otherwise. The matched rule is returned for logging.
+func (p *Policy) Authorize(method string, claims *Claims) (string, error) {
+	matched, r := p.rule(method)
+	if r == nil {
+		return "", status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+	}
+	for _, role := range r.Roles {
+		if role == AnyRole || contains(claims.Roles, role) {
+			return matched, nil
+		}
+	}
+	scopes := claims.Scopes()
+	for _, scope := range r.Scopes {
+		if contains(scopes, scope) {
+			return matched, nil
+		}
+	}
+	if claims.Peer != nil {
+		names := claims.Peer.Names()
+		for _, peer := range r.Peers {
+			if contains(names, peer) {
+				return matched, nil
+			}
+		}
+	}
+	return matched, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+}
+
+func contains(ss []string, s string) bool {
+	for _, e := range ss {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize : Authorize the caller of ctx and add the decision to the
+// call log. Services opting out of authentication with AuthFuncOverride,
+// like health, are not authorized either.
+func (p *Policy) authorize(ctx context.Context, srv interface{}, method string) error {
+	if _, ok := srv.(grpc_auth.ServiceAuthFuncOverride); ok {
+		return nil
+	}
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no verified token")
+	}
+	matched, err := p.Authorize(method, claims)
+	decision := "allow"
+	if err != nil {
+		decision = "deny"
+	}
+	ctxzap.AddFields(ctx,
+		zap.String("authz.decision", decision),
+		zap.String("authz.rule", matched),
+		zap.String("authz.subject", claims.Subject),
+	)
+	if claims.Peer != nil {
+		ctxzap.AddFields(ctx, zap.Strings("authz.peer", claims.Peer.Names()))
+	}
+	return err
+}
+
+// UnaryServerInterceptor : authorize calls with p. It has to run after
+// grpc_auth, which puts the claims into the context.
+func (p *Policy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := p.authorize(ctx, info.Server, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor : UnaryServerInterceptor for streams
+func (p *Policy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := p.authorize(ss.Context(), srv, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,137 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	ctxzap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const policy = `{"rules": [
+	{"methods": ["/api.UserService/Delete", "/api.UserService/BatchDeleteUsers"], "roles": ["admin"]},
+	{"methods": ["/api.UserService/*"], "roles": ["admin", "user"], "scopes": ["users"]},
+	{"methods": ["/api.ItemService/Get"], "roles": ["*"]},
+	{"methods": ["/api.StoreService/Get"], "peers": ["spiffe://sample/item-service"]}
+]}`
+
+func TestPolicyAuthorize(t *testing.T) {
+	p, err := auth.ParsePolicy([]byte(policy))
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		claims *auth.Claims
+		ok     bool
+	}{
+		{name: "admin deletes", method: "/api.UserService/Delete", claims: &auth.Claims{Roles: []string{"admin"}}, ok: true},
+		{name: "user deletes", method: "/api.UserService/Delete", claims: &auth.Claims{Roles: []string{"user"}}},
+		{name: "scope does not reach delete", method: "/api.UserService/Delete", claims: &auth.Claims{Scope: "users"}},
+		{name: "user gets", method: "/api.UserService/Get", claims: &auth.Claims{Roles: []string{"user"}}, ok: true},
+		{name: "scope gets", method: "/api.UserService/Get", claims: &auth.Claims{Scope: "items users"}, ok: true},
+		{name: "no role", method: "/api.UserService/Get", claims: &auth.Claims{Roles: []string{"guest"}}},
+		{name: "any role", method: "/api.ItemService/Get", claims: &auth.Claims{}, ok: true},
+		{name: "no rule", method: "/api.ItemService/Delete", claims: &auth.Claims{Roles: []string{"admin"}}},
+		{name: "peer", method: "/api.StoreService/Get", claims: &auth.Claims{Peer: &auth.Identity{
+			CommonName: "item", URIs: []string{"spiffe://sample/item-service"}}}, ok: true},
+		{name: "other peer", method: "/api.StoreService/Get", claims: &auth.Claims{Peer: &auth.Identity{
+			URIs: []string{"spiffe://sample/user-service"}}}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			_, err := p.Authorize(c.method, c.claims)
+			if c.ok && err != nil {
+				t.Errorf("want nil but actual %v", err)
+			}
+			if !c.ok && status.Code(err) != codes.PermissionDenied {
+				t.Errorf("want %v but actual %v", codes.PermissionDenied, err)
+			}
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+	}{
+		{name: "not JSON", policy: `rules`},
+		{name: "nobody allowed", policy: `{"rules": [{"methods": ["*"]}]}`},
+		{name: "short method name", policy: `{"rules": [{"methods": ["Delete"], "roles": ["admin"]}]}`},
+		{name: "method twice", policy: `{"rules": [{"methods": ["*"], "roles": ["admin"]}, {"methods": ["*"], "roles": ["user"]}]}`},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := auth.ParsePolicy([]byte(c.policy)); err == nil {
+				t.Errorf("It is expected that err is not nil but err is nil")
+			}
+		})
+	}
+}
+
+type healthServer struct{}
+
+func (healthServer) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	return ctx, nil
+}
+
+func TestPolicyUnaryServerInterceptor(t *testing.T) {
+	p, err := auth.ParsePolicy([]byte(policy))
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	cases := []struct {
+		name     string
+		claims   *auth.Claims
+		server   interface{}
+		code     codes.Code
+		decision string
+	}{
+		{name: "allowed", claims: &auth.Claims{Subject: "1", Roles: []string{"admin"}}, code: codes.OK, decision: "allow"},
+		{name: "denied", claims: &auth.Claims{Subject: "2", Roles: []string{"user"}}, code: codes.PermissionDenied, decision: "deny"},
+		{name: "no claims", code: codes.Unauthenticated},
+		{name: "auth opted out", server: healthServer{}, code: codes.OK},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			ctx := ctxzap.ToContext(context.Background(), zap.New(core))
+			if c.claims != nil {
+				ctx = auth.NewContext(ctx, c.claims)
+			}
+			info := &grpc.UnaryServerInfo{Server: c.server, FullMethod: "/api.UserService/Delete"}
+
+			_, err := p.UnaryServerInterceptor()(ctx, nil, info, handler)
+			if status.Code(err) != c.code {
+				t.Errorf("want %v but actual %v", c.code, err)
+			}
+
+			ctxzap.Extract(ctx).Info("finished unary call")
+			if decision := logs.All()[0].ContextMap()["authz.decision"]; c.decision != "" && decision != c.decision {
+				t.Errorf("want decision %q logged but actual %v", c.decision, decision)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyOfDocker(t *testing.T) {
+	if _, err := auth.LoadPolicy("../../docker/policy.json"); err != nil {
+		t.Errorf("want nil but actual %v", err)
+	}
+}
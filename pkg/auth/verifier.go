@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// minSecretLength : HS256 secrets shorter than the hash are rejected
+const minSecretLength = 32
+
+// leeway : clock skew tolerated on exp and nbf
+const leeway = 30 * time.Second
+
+// Config : how tokens are verified. At least one of Secret and JWKSFile
+// must be set. Issuer and Audience are checked when set.
+type Config struct {
+	// Secret verifies HS256 tokens
+	Secret string
+	// JWKSFile holds the public keys verifying RS256 and ES256 tokens
+	JWKSFile string
+	Issuer   string
+	Audience string
+}
+
+// ConfigFromEnv : Config of JWT_SECRET, JWT_JWKS_FILE, JWT_ISSUER and JWT_AUDIENCE
+func ConfigFromEnv() Config {
+	return Config{
+		Secret:   os.Getenv("JWT_SECRET"),
+		JWKSFile: os.Getenv("JWT_JWKS_FILE"),
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+	}
+}
+
+// Verifier : checks the signature and the registered claims of tokens
+type Verifier struct {
+	secret   []byte
+	keys     map[string]crypto.PublicKey
+	methods  []string
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewVerifier : Verifier for cfg
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{issuer: cfg.Issuer, audience: cfg.Audience, now: time.Now}
+	if cfg.Secret != "" {
+		if len(cfg.Secret) < minSecretLength {
+			return nil, xerrors.Errorf("JWT secret must be at least %d bytes", minSecretLength)
+		}
+		v.secret = []byte(cfg.Secret)
+		v.methods = append(v.methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		v.methods = append(v.methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	if len(v.methods) == 0 {
+		return nil, xerrors.New("neither JWT secret nor JWKS file is configured")
+	}
+	return v, nil
+}
+
+// Verify : the claims of token when it is signed by a configured key
+// and currently valid for the configured issuer and audience
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	parser := &jwt.Parser{ValidMethods: v.methods, SkipClaimsValidation: true}
+	if _, err := parser.ParseWithClaims(token, (*jwtClaims)(claims), v.key); err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok && ve.Inner != nil {
+			return nil, ve.Inner
+		}
+		return nil, err
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) validate(c *Claims) error {
+	now := v.now()
+	if c.ExpiresAt == 0 {
+		return xerrors.New("token has no expiration")
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(leeway)) {
+		return xerrors.New("token is expired")
+	}
+	if c.NotBefore != 0 && now.Before(time.Unix(c.NotBefore, 0).Add(-leeway)) {
+		return xerrors.New("token is not valid yet")
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return xerrors.Errorf("issuer %q is not accepted", c.Issuer)
+	}
+	if v.audience != "" && !c.Audience.Contains(v.audience) {
+		return xerrors.New("token is not issued for this service")
+	}
+	return nil
+}
+
+// key : the key verifying token, chosen by its alg and kid headers
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		if key, ok := v.publicKey(token).(*rsa.PublicKey); ok {
+			return key, nil
+		}
+	case jwt.SigningMethodES256.Alg():
+		if key, ok := v.publicKey(token).(*ecdsa.PublicKey); ok && key.Curve == elliptic.P256() {
+			return key, nil
+		}
+	}
+	return nil, xerrors.Errorf("no %s key for kid %q", token.Method.Alg(), token.Header["kid"])
+}
+
+// publicKey : the JWKS key named by kid. Without kid only a JWKS holding
+// a single key can be used.
+func (v *Verifier) publicKey(token *jwt.Token) crypto.PublicKey {
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := v.keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key
+		}
+	}
+	return nil
+}
+
+// NewAuthFunc : the AuthFunc of a Verifier for cfg, as servers pass it to grpc_auth
+func NewAuthFunc(cfg Config) (grpc_auth.AuthFunc, error) {
+	v, err := NewVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return v.AuthFunc(), nil
+}
+
+// AuthFunc : grpc_auth.AuthFunc verifying the bearer token of a call and
+// putting its claims into the context. A call without a token over a
+// connection with a verified client certificate, e.g. from another
+// service, is authenticated as that certificate: its claims only have the
+// Peer. The subject stays empty, so no certificate name is taken for a user.
+func (v *Verifier) AuthFunc() grpc_auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		peer, hasPeer := PeerIdentity(ctx)
+		token, err := grpc_auth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			if hasPeer && status.Code(err) == codes.Unauthenticated {
+				return NewContext(ctx, &Claims{Peer: peer}), nil
+			}
+			return nil, err
+		}
+		claims, err := v.Verify(token)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		}
+		claims.Peer = peer
+		return NewContext(ctx, claims), nil
+	}
+}
+
+// jwtClaims : Claims as jwt.Claims. Verify validates them itself.
+type jwtClaims Claims
+
+func (c *jwtClaims) Valid() error {
+	return nil
+}
@@ -0,0 +1,221 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func TestVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "oct", "kid": "ignored", "k": "c2VjcmV0"}
+	]}`, b64(rsaKey.N), b64(big.NewInt(int64(rsaKey.E))), b64(ecKey.X), b64(ecKey.Y))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(path, []byte(jwks), 0600); err != nil {
+		t.Fatalf("err %s", err)
+	}
+
+	v, err := auth.NewVerifier(auth.Config{Secret: secret, JWKSFile: path,
+		Issuer: "https://issuer.example", Audience: "user-service"})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+
+	now := time.Now().Unix()
+	valid := jwt.MapClaims{"sub": "42", "iss": "https://issuer.example", "aud": "user-service",
+		"exp": now + 60, "nbf": now - 60}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		c := jwt.MapClaims{}
+		for k, v := range valid {
+			c[k] = v
+		}
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	cases := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, "", valid, []byte(secret)), ok: true},
+		{name: "RS256", token: sign(t, jwt.SigningMethodRS256, "rsa", valid, rsaKey), ok: true},
+		{name: "ES256", token: sign(t, jwt.SigningMethodES256, "ec", valid, ecKey), ok: true},
+		{name: "aud array", token: sign(t, jwt.SigningMethodHS256, "",
+			with("aud", []string{"other", "user-service"}), []byte(secret)), ok: true},
+		{name: "expired", token: sign(t, jwt.SigningMethodHS256, "", with("exp", now-3600), []byte(secret))},
+		{name: "no exp", token: sign(t, jwt.SigningMethodHS256, "", with("exp", nil), []byte(secret))},
+		{name: "not before", token: sign(t, jwt.SigningMethodHS256, "", with("nbf", now+3600), []byte(secret))},
+		{name: "other issuer", token: sign(t, jwt.SigningMethodHS256, "", with("iss", "evil"), []byte(secret))},
+		{name: "other audience", token: sign(t, jwt.SigningMethodHS256, "", with("aud", "item-service"), []byte(secret))},
+		{name: "other secret", token: sign(t, jwt.SigningMethodHS256, "", valid, []byte(secret+"x"))},
+		{name: "unknown kid", token: sign(t, jwt.SigningMethodRS256, "other", valid, rsaKey)},
+		{name: "EC key for RS256", token: sign(t, jwt.SigningMethodES256, "rsa", valid, ecKey)},
+		{name: "HS512", token: sign(t, jwt.SigningMethodHS512, "", valid, []byte(secret))},
+		{name: "none", token: sign(t, jwt.SigningMethodNone, "", valid, jwt.UnsafeAllowNoneSignatureType)},
+		{name: "not a token", token: "sample_token"},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			claims, err := v.Verify(c.token)
+			if c.ok != (err == nil) {
+				t.Fatalf("want ok %v but actual %v", c.ok, err)
+			}
+			if c.ok && claims.Subject != "42" {
+				t.Errorf("want subject 42 but actual %q", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  auth.Config
+	}{
+		{name: "nothing configured", cfg: auth.Config{}},
+		{name: "short secret", cfg: auth.Config{Secret: "sample_token"}},
+		{name: "JWKS file missing", cfg: auth.Config{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := auth.NewVerifier(c.cfg); err == nil {
+				t.Errorf("It is expected that err is not nil but err is nil")
+			}
+		})
+	}
+}
+
+func TestAuthFunc(t *testing.T) {
+	v, err := auth.NewVerifier(auth.Config{Secret: secret})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	token := sign(t, jwt.SigningMethodHS256, "", jwt.MapClaims{"sub": "42", "exp": time.Now().Unix() + 60}, []byte(secret))
+
+	ctx, err := v.AuthFunc()(ctxWithToken(context.Background(), token))
+	if err != nil {
+		t.Fatalf("want nil but actual %v", err)
+	}
+	if claims, ok := auth.FromContext(ctx); !ok || claims.Subject != "42" {
+		t.Errorf("want claims of subject 42 in the context but actual %v", claims)
+	}
+
+	_, err = v.AuthFunc()(ctxWithToken(context.Background(), "sample_token"))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("want %v but actual %v", codes.Unauthenticated, err)
+	}
+}
+
+func TestNewAuthFunc(t *testing.T) {
+	if _, err := auth.NewAuthFunc(auth.Config{}); err == nil {
+		t.Errorf("It is expected that err is not nil but err is nil")
+	}
+
+	authFunc, err := auth.NewAuthFunc(auth.Config{Secret: secret})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	token := sign(t, jwt.SigningMethodHS256, "", jwt.MapClaims{"sub": "42", "exp": time.Now().Unix() + 60}, []byte(secret))
+	if _, err := authFunc(ctxWithToken(context.Background(), token)); err != nil {
+		t.Errorf("want nil but actual %v", err)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("JWT_SECRET", secret)
+	t.Setenv("JWT_JWKS_FILE", "jwks.json")
+	t.Setenv("JWT_ISSUER", "issuer")
+	t.Setenv("JWT_AUDIENCE", "audience")
+
+	want := auth.Config{Secret: secret, JWKSFile: "jwks.json", Issuer: "issuer", Audience: "audience"}
+	if cfg := auth.ConfigFromEnv(); cfg != want {
+		t.Errorf("want %+v but actual %+v", want, cfg)
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	return s
+}
+
+func b64(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func ctxWithToken(ctx context.Context, token string) context.Context {
+	md := metadata.Pairs("authorization", "bearer "+token)
+	return metautils.NiceMD(md).ToIncoming(ctx)
+}
+
+func TestAuthFuncPeer(t *testing.T) {
+	v, err := auth.NewVerifier(auth.Config{Secret: secret})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "item-service"}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}})
+
+	ctx, err = v.AuthFunc()(ctx)
+	if err != nil {
+		t.Fatalf("want nil but actual %v", err)
+	}
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.Subject != "" || claims.Peer == nil || claims.Peer.CommonName != "item-service" || len(claims.Roles) != 0 {
+		t.Errorf("want claims of the peer item-service but actual %+v", claims)
+	}
+
+	if _, err := v.AuthFunc()(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("want %v but actual %v", codes.Unauthenticated, err)
+	}
+}
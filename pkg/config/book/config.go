@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+)
+
+type Config struct {
+	Port       string
+	HTTPPort   string
+	DBHost     string
+	DBUser     string
+	DBPassword string
+	DBSchema   string
+	// PageTokenSecret signs GetAll page tokens. Replicas behind one
+	// load balancer must share it.
+	PageTokenSecret string
+	// Auth verifies bearer tokens and AuthzPolicyFile authorizes the
+	// calls of their claims, see auth.ConfigFromEnv and auth.LoadPolicy
+	Auth            auth.Config
+	AuthzPolicyFile string
+}
+
+func NewConfig() *Config {
+	var cfg Config
+	cfg.Port = os.Getenv("GRPC_PORT")
+	cfg.HTTPPort = os.Getenv("HTTP_PORT")
+	cfg.DBHost = os.Getenv("DB_HOST")
+	cfg.DBUser = os.Getenv("DB_USER")
+	cfg.DBPassword = os.Getenv("DB_PASSWORD")
+	cfg.DBSchema = os.Getenv("DB_SCHEMA")
+	cfg.PageTokenSecret = os.Getenv("PAGE_TOKEN_SECRET")
+	cfg.Auth = auth.ConfigFromEnv()
+	cfg.AuthzPolicyFile = os.Getenv("AUTHZ_POLICY_FILE")
+	return &cfg
+}
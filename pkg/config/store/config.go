@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+)
+
+// DBDriver values which select the StoreRepository backend
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
+type Config struct {
+	Port       string
+	DBDriver   string
+	DBHost     string
+	DBUser     string
+	DBPassword string
+	DBSchema   string
+	// PageTokenSecret signs GetAll page tokens. Replicas behind one
+	// load balancer must share it.
+	PageTokenSecret string
+	// Auth verifies bearer tokens and AuthzPolicyFile authorizes the
+	// calls of their claims, see auth.ConfigFromEnv and auth.LoadPolicy
+	Auth            auth.Config
+	AuthzPolicyFile string
+	// TLSCertFile and TLSKeyFile serve the gRPC listener over TLS.
+	// TLSClientCAFile verifies client certificates for mutual TLS, which
+	// are required only with TLSRequireClientCert.
+	TLSCertFile          string
+	TLSKeyFile           string
+	TLSClientCAFile      string
+	TLSRequireClientCert bool
+}
+
+func NewConfig() *Config {
+	var cfg Config
+	cfg.Port = os.Getenv("GRPC_PORT")
+	cfg.DBDriver = os.Getenv("DB_DRIVER")
+	cfg.DBHost = os.Getenv("DB_HOST")
+	cfg.DBUser = os.Getenv("DB_USER")
+	cfg.DBPassword = os.Getenv("DB_PASSWORD")
+	cfg.DBSchema = os.Getenv("DB_SCHEMA")
+	cfg.PageTokenSecret = os.Getenv("PAGE_TOKEN_SECRET")
+	cfg.Auth = auth.ConfigFromEnv()
+	cfg.AuthzPolicyFile = os.Getenv("AUTHZ_POLICY_FILE")
+	cfg.TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	cfg.TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	cfg.TLSClientCAFile = os.Getenv("TLS_CLIENT_CA_FILE")
+	cfg.TLSRequireClientCert = os.Getenv("TLS_REQUIRE_CLIENT_CERT") == "true"
+	return &cfg
+}
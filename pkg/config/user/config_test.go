@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	config "github.com/smockoro/grpc-microservice-sample/pkg/config/user"
 )
@@ -14,41 +15,61 @@ func TestNewConfig(t *testing.T) {
 		errorIsNil bool
 	}{
 		{name: "env value not loss", values: map[string]string{
-			"GRPC_PORT":   "9000",
-			"DB_HOST":     "localhost:9000",
-			"DB_USER":     "connect_user",
-			"DB_PASSWORD": "password",
-			"DB_SCHEMA":   "shema"}, errorIsNil: false},
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 		{name: "GRPC_PORT is lost", values: map[string]string{
-			"GRPC_PORT":   "",
-			"DB_HOST":     "localhost:9000",
-			"DB_USER":     "connect_user",
-			"DB_PASSWORD": "password",
-			"DB_SCHEMA":   "shema"}, errorIsNil: false},
+			"GRPC_PORT":         "",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
+		{name: "DB_DRIVER is lost", values: map[string]string{
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 		{name: "DB_HOST is lost", values: map[string]string{
-			"GRPC_PORT":   "9000",
-			"DB_HOST":     "",
-			"DB_USER":     "connect_user",
-			"DB_PASSWORD": "password",
-			"DB_SCHEMA":   "shema"}, errorIsNil: false},
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 		{name: "DB_USER is lost", values: map[string]string{
-			"GRPC_PORT":   "9000",
-			"DB_HOST":     "localhost:9000",
-			"DB_USER":     "",
-			"DB_PASSWORD": "password",
-			"DB_SCHEMA":   "shema"}, errorIsNil: false},
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 		{name: "DB_PASSWORD is lost", values: map[string]string{
-			"GRPC_PORT":   "9000",
-			"DB_HOST":     "localhost:9000",
-			"DB_USER":     "connect_user",
-			"DB_PASSWORD": "",
-			"DB_SCHEMA":   "shema"}, errorIsNil: false},
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "",
+			"DB_SCHEMA":         "shema",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 		{name: "DB_SCHEMA is lost", values: map[string]string{
-			"GRPC_PORT":   "9000",
-			"DB_HOST":     "localhost:9000",
-			"DB_USER":     "connect_user",
-			"DB_PASSWORD": "password",
-			"DB_SCHEMA":   ""}, errorIsNil: false},
+			"GRPC_PORT":         "9000",
+			"DB_DRIVER":         "mysql",
+			"DB_HOST":           "localhost:9000",
+			"DB_USER":           "connect_user",
+			"DB_PASSWORD":       "password",
+			"DB_SCHEMA":         "",
+			"PAGE_TOKEN_SECRET": "secret"}, errorIsNil: false},
 	}
 
 	for _, c := range cases {
@@ -58,6 +79,9 @@ func TestNewConfig(t *testing.T) {
 			if cfg.Port != c.values["GRPC_PORT"] {
 				t.Errorf("want %s but actual %s", c.values["GRPC_PORT"], cfg.Port)
 			}
+			if cfg.DBDriver != c.values["DB_DRIVER"] {
+				t.Errorf("want %s but actual %s", c.values["DB_DRIVER"], cfg.DBDriver)
+			}
 			if cfg.DBHost != c.values["DB_HOST"] {
 				t.Errorf("want %s but actual %s", c.values["DB_HOST"], cfg.DBHost)
 			}
@@ -70,6 +94,9 @@ func TestNewConfig(t *testing.T) {
 			if cfg.DBSchema != c.values["DB_SCHEMA"] {
 				t.Errorf("want %s but actual %s", c.values["DB_SCHEMA"], cfg.DBSchema)
 			}
+			if cfg.PageTokenSecret != c.values["PAGE_TOKEN_SECRET"] {
+				t.Errorf("want %s but actual %s", c.values["PAGE_TOKEN_SECRET"], cfg.PageTokenSecret)
+			}
 		})
 		testClearEnvs(t, c.values)
 	}
@@ -95,3 +122,27 @@ func testClearEnvs(t *testing.T, envmap map[string]string) {
 		}
 	}
 }
+
+func TestNewConfigShutdownTimeout(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "not set", value: "", want: config.DefaultShutdownTimeout},
+		{name: "duration", value: "25s", want: 25 * time.Second},
+		{name: "not a duration", value: "25", want: config.DefaultShutdownTimeout},
+		{name: "negative", value: "-1s", want: config.DefaultShutdownTimeout},
+	}
+
+	for _, c := range cases {
+		values := map[string]string{"SHUTDOWN_TIMEOUT": c.value}
+		testSetEnvs(t, values) // don't Parallel because Enviroment Value is vibration
+		t.Run(c.name, func(t *testing.T) {
+			if actual := config.NewConfig().ShutdownTimeout; actual != c.want {
+				t.Errorf("want %v but actual %v", c.want, actual)
+			}
+		})
+		testClearEnvs(t, values)
+	}
+}
@@ -0,0 +1,77 @@
+// Package domain holds the errors repositories report, free of any
+// transport. The server layer maps them to gRPC codes in one place.
+package domain
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// Kind : category of a domain error
+type Kind int
+
+const (
+	Internal      Kind = iota // failure the client can do nothing about
+	NotFound                  // the row does not exist
+	AlreadyExists             // a unique key is taken
+	Conflict                  // the row has moved on, e.g. another version
+	Unavailable               // the database can't be reached, retry later
+	Invalid                   // the request can't be carried out as given
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case AlreadyExists:
+		return "already exists"
+	case Conflict:
+		return "conflict"
+	case Unavailable:
+		return "unavailable"
+	case Invalid:
+		return "invalid"
+	default:
+		return "internal"
+	}
+}
+
+// Error : failure of a repository operation.
+// Msg is safe to show to clients, Err is the cause kept for logs.
+type Error struct {
+	Kind Kind
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Msg
+	}
+	return e.Msg + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf : an error of kind with a formatted message and no cause
+func Errorf(kind Kind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Wrap : an error of kind with msg caused by err
+func Wrap(kind Kind, msg string, err error) error {
+	return &Error{Kind: kind, Msg: msg, Err: err}
+}
+
+// KindOf : the kind of the first domain error in the chain of err,
+// Internal when there is none
+func KindOf(err error) Kind {
+	var e *Error
+	if xerrors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
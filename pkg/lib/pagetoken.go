@@ -0,0 +1,129 @@
+package lib
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page size of a GetAll request when page_size is 0 and its upper bound
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// PageTokenizer : turn a listing cursor into a page_token and back.
+// Tokens are signed, so a client can't forge a cursor, but the cursor itself
+// is only base64 encoded JSON and can be read by anyone holding the token.
+type PageTokenizer interface {
+	Encode(cursor interface{}) (string, error)
+	Decode(token string, cursor interface{}) error
+}
+
+// NewPageTokenizer : tokens are signed with HMAC-SHA256 keyed by secret.
+// An empty secret is replaced by a random one, which makes tokens valid only
+// for the lifetime of this process.
+func NewPageTokenizer(secret []byte) (PageTokenizer, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate page token secret: %v", err)
+		}
+	}
+	return &pageTokenizer{secret: secret}, nil
+}
+
+type pageTokenizer struct {
+	secret []byte
+}
+
+func (pt *pageTokenizer) Encode(cursor interface{}) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(pt.sign(payload)), nil
+}
+
+func (pt *pageTokenizer) Decode(token string, cursor interface{}) error {
+	enc := base64.RawURLEncoding
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed page token")
+	}
+	payload, err := enc.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("malformed page token")
+	}
+	mac, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("malformed page token")
+	}
+	if !hmac.Equal(mac, pt.sign(payload)) {
+		return fmt.Errorf("page token signature mismatch")
+	}
+	return json.Unmarshal(payload, cursor)
+}
+
+func (pt *pageTokenizer) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, pt.secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// idCursor : content of a page_token of a listing in ascending id order,
+// the last id of the previous page
+type idCursor struct {
+	LastID int64 `json:"last_id"`
+}
+
+// PageSize : the number of rows a GetAll page_size asks for,
+// DefaultPageSize when it is 0 and at most MaxPageSize
+func PageSize(size int32) (int, error) {
+	if size < 0 {
+		return 0, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	if size == 0 {
+		return DefaultPageSize, nil
+	}
+	if size > MaxPageSize {
+		return MaxPageSize, nil
+	}
+	return int(size), nil
+}
+
+// PageByID : resolve page_size and page_token of a GetAll request listed in
+// ascending id order to the id the page starts after and its size.
+func PageByID(pt PageTokenizer, size int32, token string) (afterID int64, limit int, err error) {
+	limit, err = PageSize(size)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if token != "" {
+		var cursor idCursor
+		if err := pt.Decode(token, &cursor); err != nil {
+			return 0, 0, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		afterID = cursor.LastID
+	}
+
+	return afterID, limit, nil
+}
+
+// NextPageByID : page_token of the page following the one which ends with lastID
+func NextPageByID(pt PageTokenizer, lastID int64) (string, error) {
+	token, err := pt.Encode(idCursor{LastID: lastID})
+	if err != nil {
+		return "", status.Error(codes.Internal, "failed to issue next page token")
+	}
+	return token, nil
+}
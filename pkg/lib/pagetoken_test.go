@@ -0,0 +1,108 @@
+package lib_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+)
+
+type cursor struct {
+	LastID int64 `json:"last_id"`
+}
+
+func TestPageTokenizer(t *testing.T) {
+	pt, err := lib.NewPageTokenizer([]byte("secret"))
+	if err != nil {
+		t.Fatalf("error was not expected: %s", err)
+	}
+	token, err := pt.Encode(cursor{LastID: 42})
+	if err != nil {
+		t.Fatalf("error was not expected while Encode: %s", err)
+	}
+	if strings.Contains(token, "42") {
+		t.Errorf("token %s exposes the cursor", token)
+	}
+
+	other, _ := lib.NewPageTokenizer([]byte("other"))
+	forged, _ := other.Encode(cursor{LastID: 1})
+	payload := strings.Split(forged, ".")[0]
+	signature := strings.Split(token, ".")[1]
+
+	cases := []struct {
+		name       string
+		token      string
+		want       int64
+		errorIsNil bool
+	}{
+		{name: "round trip", token: token, want: 42, errorIsNil: true},
+		{name: "signed by other secret", token: forged, errorIsNil: false},
+		{name: "payload swapped", token: payload + "." + signature, errorIsNil: false},
+		{name: "no signature", token: payload, errorIsNil: false},
+		{name: "not base64", token: "!!!.???", errorIsNil: false},
+		{name: "empty", token: "", errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			var actual cursor
+			err := pt.Decode(c.token, &actual)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err == nil && actual.LastID != c.want {
+				t.Errorf("want %d but actual %d", c.want, actual.LastID)
+			}
+		})
+	}
+}
+
+func TestNewPageTokenizerRandomSecret(t *testing.T) {
+	a, _ := lib.NewPageTokenizer(nil)
+	b, _ := lib.NewPageTokenizer(nil)
+
+	token, _ := a.Encode(cursor{LastID: 1})
+	if err := b.Decode(token, &cursor{}); err == nil {
+		t.Errorf("tokens of random secrets are interchangeable")
+	}
+}
+
+func TestPageByID(t *testing.T) {
+	pt, _ := lib.NewPageTokenizer([]byte("secret"))
+	next, err := lib.NextPageByID(pt, 42)
+	if err != nil {
+		t.Fatalf("error was not expected while NextPageByID: %s", err)
+	}
+
+	cases := []struct {
+		name       string
+		size       int32
+		token      string
+		afterID    int64
+		limit      int
+		errorIsNil bool
+	}{
+		{name: "first page", size: 2, afterID: 0, limit: 2, errorIsNil: true},
+		{name: "next page", size: 2, token: next, afterID: 42, limit: 2, errorIsNil: true},
+		{name: "default size", size: 0, limit: lib.DefaultPageSize, errorIsNil: true},
+		{name: "capped size", size: lib.MaxPageSize + 1, limit: lib.MaxPageSize, errorIsNil: true},
+		{name: "negative size", size: -1, errorIsNil: false},
+		{name: "broken token", size: 2, token: "broken", errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			afterID, limit, err := lib.PageByID(pt, c.size, c.token)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if afterID != c.afterID || limit != c.limit {
+				t.Errorf("want (%d, %d) but actual (%d, %d)", c.afterID, c.limit, afterID, limit)
+			}
+		})
+	}
+}
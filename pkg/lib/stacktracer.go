@@ -1,7 +1,11 @@
 package lib
 
 import (
+	"fmt"
+
 	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type StackTracer interface {
@@ -14,6 +18,44 @@ func NewStackTracer() StackTracer {
 
 type stackTracer struct{}
 
+// Wrap : annotate err with message and the frame of the caller.
+// The result reports the gRPC status of err, so clients still receive its
+// code and message while "%+v" prints the whole chain for server logs.
 func (st *stackTracer) Wrap(message string, err error) error {
-	return xerrors.Errorf(message+": %w", err)
+	return &wrapError{msg: message, err: err, frame: xerrors.Caller(1)}
+}
+
+type wrapError struct {
+	msg   string
+	err   error
+	frame xerrors.Frame
+}
+
+func (e *wrapError) Error() string {
+	return fmt.Sprint(e)
+}
+
+func (e *wrapError) Format(s fmt.State, v rune) {
+	xerrors.FormatError(e, s, v)
+}
+
+func (e *wrapError) FormatError(p xerrors.Printer) error {
+	p.Print(e.msg)
+	e.frame.Format(p)
+	return e.err
+}
+
+func (e *wrapError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus : the status of the first error in the chain that has one,
+// codes.Unknown with the whole message when none has
+func (e *wrapError) GRPCStatus() *status.Status {
+	for err := e.err; err != nil; err = xerrors.Unwrap(err) {
+		if se, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			return se.GRPCStatus()
+		}
+	}
+	return status.New(codes.Unknown, e.Error())
 }
@@ -0,0 +1,54 @@
+package lib_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStackTracerWrap(t *testing.T) {
+	st := lib.NewStackTracer()
+	notFound := status.Error(codes.NotFound, "ID='1' is not found")
+
+	cases := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{name: "status error", err: st.Wrap("can't get user by id", notFound),
+			code: codes.NotFound, message: "ID='1' is not found"},
+		{name: "wrapped twice", err: st.Wrap("outer", st.Wrap("can't get user by id", notFound)),
+			code: codes.NotFound, message: "ID='1' is not found"},
+		{name: "status under xerrors", err: st.Wrap("outer", xerrors.Errorf("inner: %w", notFound)),
+			code: codes.NotFound, message: "ID='1' is not found"},
+		{name: "plain error", err: st.Wrap("can't create user", fmt.Errorf("broken")),
+			code: codes.Unknown, message: "can't create user: broken"},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			s := status.Convert(c.err)
+			if s.Code() != c.code || s.Message() != c.message {
+				t.Errorf("want %v %q but actual %v %q", c.code, c.message, s.Code(), s.Message())
+			}
+		})
+	}
+
+	err := st.Wrap("can't get user by id", notFound)
+	if !xerrors.Is(err, notFound) {
+		t.Errorf("want %v in the chain of %v", notFound, err)
+	}
+	if want := "can't get user by id: " + notFound.Error(); err.Error() != want {
+		t.Errorf("want %q but actual %q", want, err.Error())
+	}
+	if detail := fmt.Sprintf("%+v", err); !strings.Contains(detail, "stacktracer_test.go") {
+		t.Errorf("want the frame of the caller in %q", detail)
+	}
+}
@@ -0,0 +1,229 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// Dialect names accepted by NewMigrator
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint NOT NULL,
+	name varchar(255) NOT NULL,
+	applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (version)
+)`
+
+// Migration : one numbered schema change.
+// Up and Down hold one SQL statement per element because the MySQL driver
+// refuses multi statement queries.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// Status : a Migration and whether schema_migrations records it
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// Migrator : apply and roll back Migrations, tracking them in schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	bind       func(n int) string
+	migrations []Migration
+}
+
+// NewMigrator : migrations must be sorted by strictly increasing positive Version
+func NewMigrator(db *sql.DB, dialect string, migrations []Migration) (*Migrator, error) {
+	m := &Migrator{db: db, migrations: migrations}
+	switch dialect {
+	case DialectMySQL:
+		m.bind = func(int) string { return "?" }
+	case DialectPostgres:
+		m.bind = func(n int) string { return fmt.Sprintf("$%d", n) }
+	default:
+		return nil, fmt.Errorf("dialect %s is not supported", dialect)
+	}
+
+	var last int64
+	for _, mig := range migrations {
+		if mig.Version <= last {
+			return nil, fmt.Errorf("migration %d %s is out of order", mig.Version, mig.Name)
+		}
+		last = mig.Version
+	}
+
+	return m, nil
+}
+
+// Up : apply every pending migration in order and return the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, mig := range m.migrations {
+		if applied[mig.Version] {
+			continue
+		}
+		insert := fmt.Sprintf("INSERT INTO schema_migrations(version, name) VALUES(%s, %s)",
+			m.bind(1), m.bind(2))
+		if err := m.exec(ctx, mig.Up, insert, mig.Version, mig.Name); err != nil {
+			return done, fmt.Errorf("failed to apply migration %d %s: %v", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
+
+// Down : roll back the latest applied migration.
+// It returns nil when nothing is applied.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest int64
+	for version := range applied {
+		if version > latest {
+			latest = version
+		}
+	}
+	if latest == 0 {
+		return nil, nil
+	}
+
+	for _, mig := range m.migrations {
+		if mig.Version != latest {
+			continue
+		}
+		remove := fmt.Sprintf("DELETE FROM schema_migrations WHERE version = %s", m.bind(1))
+		if err := m.exec(ctx, mig.Down, remove, mig.Version); err != nil {
+			return nil, fmt.Errorf("failed to roll back migration %d %s: %v", mig.Version, mig.Name, err)
+		}
+		return &mig, nil
+	}
+
+	return nil, fmt.Errorf("applied migration %d is unknown to this binary", latest)
+}
+
+// Status : every known migration with its applied state
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		list = append(list, Status{Migration: mig, Applied: applied[mig.Version]})
+	}
+
+	return list, nil
+}
+
+// exec : run statements and the schema_migrations bookkeeping in one
+// transaction. MySQL commits DDL implicitly, so there a failure can leave
+// earlier statements of the migration in place.
+func (m *Migrator) exec(ctx context.Context, stmts []string, record string, args ...interface{}) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]bool, error) {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %v", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to select schema_migrations: %v", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// Run : execute the `migrate up|down|status` subcommand and report to w
+func Run(ctx context.Context, m *Migrator, args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx)
+		for _, mig := range done {
+			fmt.Fprintf(w, "applied %d %s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(done) == 0 {
+			fmt.Fprintln(w, "no pending migration")
+		}
+	case "down":
+		mig, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if mig == nil {
+			fmt.Fprintln(w, "no applied migration")
+			return nil
+		}
+		fmt.Fprintf(w, "rolled back %d %s\n", mig.Version, mig.Name)
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range list {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%d %s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %s: usage: migrate up|down|status", args[0])
+	}
+
+	return nil
+}
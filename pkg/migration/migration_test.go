@@ -0,0 +1,198 @@
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3" // Register SQLite Driver
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var testMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_users",
+		Up:      []string{"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"},
+		Down:    []string{"DROP TABLE users"},
+	},
+	{
+		Version: 2,
+		Name:    "add_users_age",
+		Up:      []string{"ALTER TABLE users ADD COLUMN age INTEGER"},
+		Down: []string{
+			"CREATE TABLE users_old (id INTEGER PRIMARY KEY, name TEXT)",
+			"INSERT INTO users_old SELECT id, name FROM users",
+			"DROP TABLE users",
+			"ALTER TABLE users_old RENAME TO users",
+		},
+	},
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening sqlite", err)
+	}
+	db.SetMaxOpenConns(1) // every connection to :memory: is a new database
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestNewMigrator(t *testing.T) {
+	cases := []struct {
+		name       string
+		dialect    string
+		migrations []migration.Migration
+		errorIsNil bool
+	}{
+		{name: "mysql", dialect: migration.DialectMySQL, migrations: testMigrations, errorIsNil: true},
+		{name: "postgres", dialect: migration.DialectPostgres, migrations: testMigrations, errorIsNil: true},
+		{name: "unknown dialect", dialect: "oracle", migrations: testMigrations, errorIsNil: false},
+		{name: "out of order", dialect: migration.DialectMySQL, migrations: []migration.Migration{
+			testMigrations[1], testMigrations[0]}, errorIsNil: false},
+		{name: "zero version", dialect: migration.DialectMySQL, migrations: []migration.Migration{
+			{Version: 0, Name: "zero"}}, errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := migration.NewMigrator(nil, c.dialect, c.migrations)
+			if (err == nil) != c.errorIsNil {
+				t.Errorf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+		})
+	}
+}
+
+func TestUpDown(t *testing.T) {
+	for _, dialect := range []string{migration.DialectMySQL, migration.DialectPostgres} {
+		dialect := dialect // cascading
+		t.Run(dialect, func(t *testing.T) {
+			db := openDB(t)
+			m, err := migration.NewMigrator(db, dialect, testMigrations)
+			if err != nil {
+				t.Fatalf("error was not expected: %s", err)
+			}
+			ctx := context.Background()
+
+			done, err := m.Up(ctx)
+			if err != nil {
+				t.Fatalf("error was not expected while Up: %s", err)
+			}
+			if len(done) != 2 {
+				t.Fatalf("want 2 migrations applied but actual %d", len(done))
+			}
+			if _, err := db.Exec("INSERT INTO users(name, age) VALUES('Bob', 11)"); err != nil {
+				t.Errorf("schema is not migrated: %s", err)
+			}
+
+			if done, err = m.Up(ctx); err != nil || len(done) != 0 {
+				t.Errorf("want no migration applied but actual %v, %v", done, err)
+			}
+
+			mig, err := m.Down(ctx)
+			if err != nil {
+				t.Fatalf("error was not expected while Down: %s", err)
+			}
+			if mig == nil || mig.Version != 2 {
+				t.Fatalf("want version 2 rolled back but actual %v", mig)
+			}
+			status, err := m.Status(ctx)
+			if err != nil {
+				t.Fatalf("error was not expected while Status: %s", err)
+			}
+			if !status[0].Applied || status[1].Applied {
+				t.Errorf("want only version 1 applied but actual %v", status)
+			}
+
+			if mig, err = m.Down(ctx); err != nil || mig.Version != 1 {
+				t.Fatalf("want version 1 rolled back but actual %v, %v", mig, err)
+			}
+			if mig, err = m.Down(ctx); err != nil || mig != nil {
+				t.Errorf("want nothing rolled back but actual %v, %v", mig, err)
+			}
+		})
+	}
+}
+
+func TestUpFailure(t *testing.T) {
+	db := openDB(t)
+	broken := append([]migration.Migration{}, testMigrations[0], migration.Migration{
+		Version: 2,
+		Name:    "broken",
+		Up:      []string{"CREATE TABLE items (id INTEGER PRIMARY KEY)", "NOT SQL"},
+	})
+	m, _ := migration.NewMigrator(db, migration.DialectMySQL, broken)
+	ctx := context.Background()
+
+	done, err := m.Up(ctx)
+	if err == nil {
+		t.Fatalf("error was expected while Up")
+	}
+	if len(done) != 1 {
+		t.Errorf("want 1 migration applied before failure but actual %d", len(done))
+	}
+
+	status, _ := m.Status(ctx)
+	if status[1].Applied {
+		t.Errorf("failed migration is recorded as applied")
+	}
+	if _, err := db.Exec("SELECT id FROM items"); err == nil {
+		t.Errorf("failed migration is not rolled back")
+	}
+}
+
+func TestDownUnknownVersion(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+
+	newer, _ := migration.NewMigrator(db, migration.DialectMySQL, testMigrations)
+	if _, err := newer.Up(ctx); err != nil {
+		t.Fatalf("error was not expected while Up: %s", err)
+	}
+
+	older, _ := migration.NewMigrator(db, migration.DialectMySQL, testMigrations[:1])
+	if _, err := older.Down(ctx); err == nil {
+		t.Errorf("error was expected while Down of an unknown version")
+	}
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name       string
+		args       []string
+		output     string
+		errorIsNil bool
+	}{
+		{name: "status", args: []string{"status"},
+			output: "1 create_users pending\n2 add_users_age pending\n", errorIsNil: true},
+		{name: "up", args: []string{"up"},
+			output: "applied 1 create_users\napplied 2 add_users_age\n", errorIsNil: true},
+		{name: "down", args: []string{"down"},
+			output: "no applied migration\n", errorIsNil: true},
+		{name: "no command", args: []string{}, errorIsNil: false},
+		{name: "unknown command", args: []string{"redo"}, errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			m, _ := migration.NewMigrator(openDB(t), migration.DialectMySQL, testMigrations)
+			var out bytes.Buffer
+
+			err := migration.Run(context.Background(), m, c.args, &out)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if out.String() != c.output {
+				t.Errorf("want %q but actual %q", c.output, out.String())
+			}
+		})
+	}
+}
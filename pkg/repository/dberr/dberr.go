@@ -0,0 +1,84 @@
+// Package dberr turns errors of the database drivers into domain errors,
+// so each repository does not have to know the error codes of its driver.
+package dberr
+
+import (
+	"database/sql/driver"
+	"net"
+	"syscall"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"golang.org/x/xerrors"
+)
+
+// MySQL server error numbers
+const (
+	mysqlDuplicateEntry    = 1062
+	mysqlLockWaitTimeout   = 1205
+	mysqlDeadlock          = 1213
+	mysqlNoReferencedRow   = 1452
+	mysqlServerShutdown    = 1053
+	mysqlTooManyConnection = 1040
+)
+
+// PostgreSQL SQLSTATE codes and classes
+const (
+	pqUniqueViolation      = "23505"
+	pqForeignKeyViolation  = "23503"
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+	pqConnectionException  = "08"
+	pqOperatorIntervention = "57"
+)
+
+// Wrap : the domain error of err returned by a database call described
+// by msg. msg reaches clients, err only the logs.
+func Wrap(msg string, err error) error {
+	return domain.Wrap(KindOf(err), msg, err)
+}
+
+// KindOf : the domain kind of a driver error
+func KindOf(err error) domain.Kind {
+	var myErr *mysql.MySQLError
+	if xerrors.As(err, &myErr) {
+		switch myErr.Number {
+		case mysqlDuplicateEntry:
+			return domain.AlreadyExists
+		case mysqlLockWaitTimeout, mysqlDeadlock:
+			return domain.Conflict
+		case mysqlNoReferencedRow:
+			return domain.Invalid
+		case mysqlServerShutdown, mysqlTooManyConnection:
+			return domain.Unavailable
+		}
+		return domain.Internal
+	}
+
+	var pqErr *pq.Error
+	if xerrors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == pqUniqueViolation:
+			return domain.AlreadyExists
+		case pqErr.Code == pqForeignKeyViolation:
+			return domain.Invalid
+		case pqErr.Code == pqSerializationFailure, pqErr.Code == pqDeadlockDetected:
+			return domain.Conflict
+		case pqErr.Code.Class() == pqConnectionException, pqErr.Code.Class() == pqOperatorIntervention:
+			return domain.Unavailable
+		}
+		return domain.Internal
+	}
+
+	if xerrors.Is(err, driver.ErrBadConn) || xerrors.Is(err, mysql.ErrInvalidConn) ||
+		xerrors.Is(err, syscall.ECONNREFUSED) {
+		return domain.Unavailable
+	}
+	var netErr net.Error
+	if xerrors.As(err, &netErr) {
+		return domain.Unavailable
+	}
+
+	return domain.Internal
+}
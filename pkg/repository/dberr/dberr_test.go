@@ -0,0 +1,47 @@
+package dberr_test
+
+import (
+	"database/sql/driver"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	"golang.org/x/xerrors"
+)
+
+func TestKindOf(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		kind domain.Kind
+	}{
+		{name: "mysql duplicate entry", err: &mysql.MySQLError{Number: 1062}, kind: domain.AlreadyExists},
+		{name: "mysql deadlock", err: &mysql.MySQLError{Number: 1213}, kind: domain.Conflict},
+		{name: "mysql syntax error", err: &mysql.MySQLError{Number: 1064}, kind: domain.Internal},
+		{name: "pq unique violation", err: &pq.Error{Code: "23505"}, kind: domain.AlreadyExists},
+		{name: "pq serialization failure", err: &pq.Error{Code: "40001"}, kind: domain.Conflict},
+		{name: "pq admin shutdown", err: &pq.Error{Code: "57P01"}, kind: domain.Unavailable},
+		{name: "bad conn", err: driver.ErrBadConn, kind: domain.Unavailable},
+		{name: "connection refused", err: xerrors.Errorf("dial: %w", syscall.ECONNREFUSED), kind: domain.Unavailable},
+		{name: "other", err: xerrors.New("broken"), kind: domain.Internal},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if actual := dberr.KindOf(c.err); actual != c.kind {
+				t.Errorf("want %v but actual %v", c.kind, actual)
+			}
+			err := dberr.Wrap("failed to insert user", c.err)
+			if actual := domain.KindOf(err); actual != c.kind {
+				t.Errorf("want %v but actual %v", c.kind, actual)
+			}
+			if !xerrors.Is(err, c.err) {
+				t.Errorf("want %v in the chain of %v", c.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+)
+
+// itemRepository : ItemRepository kept in process memory.
+// IDs are issued from 1 like a SERIAL column and are never reused.
+type itemRepository struct {
+	mu     sync.RWMutex
+	lastID int64
+	items  map[int64]api.Item
+}
+
+func NewItemRepository() repo.ItemRepository {
+	return &itemRepository{
+		items: map[int64]api.Item{},
+	}
+}
+
+func (u *itemRepository) Insert(ctx context.Context, item *api.Item) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.lastID++
+	u.items[u.lastID] = api.Item{
+		Id:          u.lastID,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       item.Price,
+		Version:     1,
+	}
+
+	return u.lastID, nil
+}
+
+func (u *itemRepository) SelectByID(ctx context.Context, id int64) (*api.Item, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	item, ok := u.items[id]
+	if !ok {
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return copyItem(item), nil
+}
+
+func (u *itemRepository) SelectAll(ctx context.Context, filter repo.Filter, order repo.Order,
+	page repo.Page) ([]*api.Item, error) {
+	list, err := u.list(filter, order)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.After != nil {
+		start := sort.Search(len(list), func(i int) bool {
+			return lessItem(order, page.After, list[i])
+		})
+		list = list[start:]
+	}
+	if len(list) > page.Limit {
+		list = list[:page.Limit]
+	}
+
+	return list, nil
+}
+
+func (u *itemRepository) SelectEach(ctx context.Context, filter repo.Filter, order repo.Order,
+	fn func(*api.Item) error) error {
+	// the lock is not held across fn, so a slow consumer does not block writers
+	list, err := u.list(filter, order)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// list : copies of the items matching filter sorted by order
+func (u *itemRepository) list(filter repo.Filter, order repo.Order) ([]*api.Item, error) {
+	switch order.Field {
+	case "", repo.OrderByID, repo.OrderByName, repo.OrderByPrice:
+	default:
+		return nil, domain.Errorf(domain.Invalid, "items can't be ordered by %s", order.Field)
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	list := []*api.Item{}
+	for _, item := range u.items {
+		if matchItem(filter, item) {
+			list = append(list, copyItem(item))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return lessItem(order, list[i], list[j]) })
+
+	return list, nil
+}
+
+func matchItem(f repo.Filter, item api.Item) bool {
+	return strings.HasPrefix(item.Name, f.NamePrefix) &&
+		(f.MinPrice == nil || item.Price >= *f.MinPrice) &&
+		(f.MaxPrice == nil || item.Price <= *f.MaxPrice)
+}
+
+// lessItem : whether a sorts before b, ties are broken by ascending id
+func lessItem(o repo.Order, a, b *api.Item) bool {
+	var cmp int
+	switch o.Field {
+	case repo.OrderByName:
+		cmp = strings.Compare(a.Name, b.Name)
+	case repo.OrderByPrice:
+		cmp = compareInt(a.Price, b.Price)
+	default:
+		cmp = compareInt(a.Id, b.Id)
+	}
+	if o.Desc {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return a.Id < b.Id
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (u *itemRepository) Update(ctx context.Context, item *api.Item, fields []string) (int64, error) {
+	if len(fields) == 0 {
+		return -1, domain.Errorf(domain.Invalid, "no item field to update")
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.items[item.Id]
+	for _, field := range fields {
+		switch field {
+		case repo.FieldName:
+			stored.Name = item.Name
+		case repo.FieldDescription:
+			stored.Description = item.Description
+		case repo.FieldPrice:
+			stored.Price = item.Price
+		default:
+			return -1, domain.Errorf(domain.Invalid, "item field %s can't be updated", field)
+		}
+	}
+	if !ok {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", item.Id)
+	}
+	if err := checkVersion(stored, item.Version); err != nil {
+		return -1, err
+	}
+	stored.Version++
+	u.items[item.Id] = stored
+
+	return 1, nil
+}
+
+func (u *itemRepository) Delete(ctx context.Context, id int64, version int64) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.items[id]
+	if !ok {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+	if err := checkVersion(stored, version); err != nil {
+		return -1, err
+	}
+	delete(u.items, id)
+
+	return 1, nil
+}
+
+func (u *itemRepository) InsertBatch(ctx context.Context, items []*api.Item) ([]int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		u.lastID++
+		u.items[u.lastID] = api.Item{
+			Id:          u.lastID,
+			Name:        item.Name,
+			Description: item.Description,
+			Price:       item.Price,
+			Version:     1,
+		}
+		ids = append(ids, u.lastID)
+	}
+
+	return ids, nil
+}
+
+func (u *itemRepository) SelectByIDs(ctx context.Context, ids []int64) ([]*api.Item, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	list := []*api.Item{}
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		if item, ok := u.items[id]; ok && !seen[id] {
+			list = append(list, copyItem(item))
+			seen[id] = true
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+
+	return list, nil
+}
+
+func (u *itemRepository) DeleteBatch(ctx context.Context, keys []repo.Key) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// check every key before deleting any so a failure deletes nothing
+	for _, key := range keys {
+		stored, ok := u.items[key.ID]
+		if !ok {
+			return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", key.ID)
+		}
+		if err := checkVersion(stored, key.Version); err != nil {
+			return -1, err
+		}
+	}
+	for _, key := range keys {
+		delete(u.items, key.ID)
+	}
+
+	return int64(len(keys)), nil
+}
+
+// checkVersion : a Conflict unless version is 0 or the version of stored
+func checkVersion(stored api.Item, version int64) error {
+	if version != 0 && version != stored.Version {
+		return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+			stored.Id, stored.Version, version)
+	}
+	return nil
+}
+
+// copyItem : hand out a fresh message so callers cannot mutate the store
+func copyItem(item api.Item) *api.Item {
+	return &api.Item{
+		Id:          item.Id,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       item.Price,
+		Version:     item.Version,
+	}
+}
@@ -0,0 +1,269 @@
+package repository_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/item"
+	itemrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	"golang.org/x/xerrors"
+)
+
+func TestInsert(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		id, err := ir.Insert(ctx, &api.Item{Name: "Pen", Description: "black ink", Price: 100})
+		if err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+		if id != want {
+			t.Errorf("want id %d but actual %d", want, id)
+		}
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	item := &api.Item{Name: "Pen", Description: "black ink", Price: 100}
+	id, _ := ir.Insert(ctx, item)
+
+	actual, err := ir.SelectByID(ctx, id)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	if actual.Id != id || actual.Name != item.Name ||
+		actual.Description != item.Description || actual.Price != item.Price {
+		t.Errorf("want %v but actual %v", item, actual)
+	}
+
+	actual.Name = "Notebook"
+	if stored, _ := ir.SelectByID(ctx, id); stored.Name != item.Name {
+		t.Errorf("stored item is mutated through returned value: %v", stored)
+	}
+
+	if _, err := ir.SelectByID(ctx, id+1); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+}
+
+var allPage = itemrepo.Page{Limit: 100}
+
+func TestSelectAll(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+
+	list, err := ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{}, allPage)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("want empty list but actual %v, %v", list, err)
+	}
+
+	for _, name := range []string{"Pen", "Notebook", "Eraser"} {
+		ir.Insert(ctx, &api.Item{Name: name})
+	}
+	list, err = ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{}, allPage)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("want 3 items but actual %d", len(list))
+	}
+	for i, item := range list {
+		if item.Id != int64(i+1) {
+			t.Errorf("want id %d but actual %d", i+1, item.Id)
+		}
+	}
+
+	list, err = ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{}, itemrepo.Page{After: list[0], Limit: 1})
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != 1 || list[0].Id != 2 {
+		t.Errorf("want only id 2 but actual %v", list)
+	}
+}
+
+func TestSelectAllFilterOrder(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	for _, item := range []*api.Item{
+		{Name: "Pen", Price: 100},
+		{Name: "Pencil", Price: 80},
+		{Name: "Notebook", Price: 300},
+		{Name: "Pen", Price: 120},
+	} {
+		ir.Insert(ctx, item)
+	}
+	price := func(v int64) *int64 { return &v }
+
+	cases := []struct {
+		name    string
+		filter  itemrepo.Filter
+		order   itemrepo.Order
+		wantIDs []int64
+	}{
+		{name: "name prefix", filter: itemrepo.Filter{NamePrefix: "Pen"}, wantIDs: []int64{1, 2, 4}},
+		{name: "price range", filter: itemrepo.Filter{MinPrice: price(90), MaxPrice: price(200)},
+			wantIDs: []int64{1, 4}},
+		{name: "price desc", order: itemrepo.Order{Field: itemrepo.OrderByPrice, Desc: true},
+			wantIDs: []int64{3, 4, 1, 2}},
+		{name: "name ties by id", filter: itemrepo.Filter{MaxPrice: price(200)},
+			order: itemrepo.Order{Field: itemrepo.OrderByName}, wantIDs: []int64{1, 4, 2}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			ids := []int64{}
+			page := itemrepo.Page{Limit: 1}
+			for {
+				list, err := ir.SelectAll(ctx, c.filter, c.order, page)
+				if err != nil {
+					t.Fatalf("error was not expected while Select All stats: %s", err)
+				}
+				if len(list) == 0 {
+					break
+				}
+				ids = append(ids, list[0].Id)
+				page.After = list[0]
+			}
+			if !reflect.DeepEqual(ids, c.wantIDs) {
+				t.Errorf("want %v but actual %v", c.wantIDs, ids)
+			}
+		})
+	}
+
+	_, err := ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{Field: "description"}, allPage)
+	if domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	id, _ := ir.Insert(ctx, &api.Item{Name: "Pen", Description: "black ink"})
+	fields := []string{itemrepo.FieldName, itemrepo.FieldPrice}
+
+	rows, err := ir.Update(ctx, &api.Item{Id: id, Name: "Notebook", Price: 200}, fields)
+	if err != nil || rows != 1 {
+		t.Fatalf("want 1 row updated but actual %d, %v", rows, err)
+	}
+	item, _ := ir.SelectByID(ctx, id)
+	if item.Name != "Notebook" || item.Price != 200 {
+		t.Errorf("item is not updated: %v", item)
+	}
+	if item.Description != "black ink" {
+		t.Errorf("field out of the update is overwritten: %v", item)
+	}
+
+	if _, err := ir.Update(ctx, &api.Item{Id: id + 1, Name: "Eraser"}, fields); err == nil {
+		t.Errorf("error was expected while Update stats")
+	}
+	if _, err := ir.Update(ctx, &api.Item{Id: id}, []string{"id"}); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	id, _ := ir.Insert(ctx, &api.Item{Name: "Pen"})
+	fields := []string{itemrepo.FieldPrice}
+
+	if item, _ := ir.SelectByID(ctx, id); item.Version != 1 {
+		t.Fatalf("want version 1 but actual %d", item.Version)
+	}
+	if _, err := ir.Update(ctx, &api.Item{Id: id, Price: 100, Version: 1}, fields); err != nil {
+		t.Fatalf("error was not expected while Update stats: %s", err)
+	}
+	if _, err := ir.Update(ctx, &api.Item{Id: id, Price: 200, Version: 1}, fields); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+	if _, err := ir.Update(ctx, &api.Item{Id: id, Price: 300}, fields); err != nil {
+		t.Fatalf("error was not expected while Update stats: %s", err)
+	}
+	if item, _ := ir.SelectByID(ctx, id); item.Version != 3 || item.Price != 300 {
+		t.Errorf("want price 300 at version 3 but actual %v", item)
+	}
+
+	if _, err := ir.Delete(ctx, id, 2); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+	if rows, err := ir.Delete(ctx, id, 3); err != nil || rows != 1 {
+		t.Errorf("want 1 row deleted but actual %d, %v", rows, err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	id, _ := ir.Insert(ctx, &api.Item{Name: "Pen"})
+
+	rows, err := ir.Delete(ctx, id, 0)
+	if err != nil || rows != 1 {
+		t.Fatalf("want 1 row deleted but actual %d, %v", rows, err)
+	}
+	if _, err := ir.Delete(ctx, id, 0); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+
+	next, _ := ir.Insert(ctx, &api.Item{Name: "Notebook", Price: 200})
+	if next == id {
+		t.Errorf("deleted id %d is reused", id)
+	}
+}
+
+func TestConcurrentInsert(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ir.Insert(ctx, &api.Item{Name: "Pen"})
+			ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{}, allPage)
+		}()
+	}
+	wg.Wait()
+
+	list, _ := ir.SelectAll(ctx, itemrepo.Filter{}, itemrepo.Order{}, allPage)
+	if len(list) != 50 {
+		t.Errorf("want 50 items but actual %d", len(list))
+	}
+}
+
+func TestSelectEach(t *testing.T) {
+	ir := repo.NewItemRepository()
+	ctx := context.Background()
+	for _, name := range []string{"Pen", "Notebook", "Eraser"} {
+		ir.Insert(ctx, &api.Item{Name: name})
+	}
+
+	visited := []int64{}
+	err := ir.SelectEach(ctx, itemrepo.Filter{}, itemrepo.Order{}, func(item *api.Item) error {
+		visited = append(visited, item.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error was not expected while Select Each stats: %s", err)
+	}
+	if len(visited) != 3 || visited[0] != 1 || visited[2] != 3 {
+		t.Errorf("want ids [1 2 3] but actual %v", visited)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = ir.SelectEach(canceled, itemrepo.Filter{}, itemrepo.Order{}, func(*api.Item) error { return nil })
+	if !xerrors.Is(err, context.Canceled) {
+		t.Errorf("want %v but actual %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,15 @@
+package repository_test
+
+import (
+	"testing"
+
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/user"
+	userrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"github.com/smockoro/grpc-microservice-sample/testdata/contract"
+)
+
+func TestContract(t *testing.T) {
+	contract.TestUserRepository(t, func(t *testing.T) userrepo.UserRepository {
+		return repo.NewUserRepository()
+	})
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+// userRepository : UserRepository kept in process memory.
+// IDs are issued from 1 like AUTO_INCREMENT and are never reused.
+type userRepository struct {
+	mu     sync.RWMutex
+	lastID int64
+	users  map[int64]api.User
+}
+
+func NewUserRepository() repo.UserRepository {
+	return &userRepository{
+		users: map[int64]api.User{},
+	}
+}
+
+func (u *userRepository) Insert(ctx context.Context, user *api.User) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.lastID++
+	u.users[u.lastID] = api.User{
+		Id:      u.lastID,
+		Name:    user.Name,
+		Age:     user.Age,
+		Mail:    user.Mail,
+		Address: user.Address,
+		Version: 1,
+	}
+
+	return u.lastID, nil
+}
+
+func (u *userRepository) SelectByID(ctx context.Context, id int64) (*api.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, ok := u.users[id]
+	if !ok {
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return copyUser(user), nil
+}
+
+func (u *userRepository) SelectAll(ctx context.Context, filter repo.Filter, order repo.Order,
+	page repo.Page) ([]*api.User, error) {
+	list, err := u.list(filter, order)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.After != nil {
+		start := sort.Search(len(list), func(i int) bool {
+			return lessUser(order, page.After, list[i])
+		})
+		list = list[start:]
+	}
+	if len(list) > page.Limit {
+		list = list[:page.Limit]
+	}
+
+	return list, nil
+}
+
+func (u *userRepository) SelectEach(ctx context.Context, filter repo.Filter, order repo.Order,
+	fn func(*api.User) error) error {
+	// the lock is not held across fn, so a slow consumer does not block writers
+	list, err := u.list(filter, order)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range list {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// list : copies of the users matching filter sorted by order
+func (u *userRepository) list(filter repo.Filter, order repo.Order) ([]*api.User, error) {
+	switch order.Field {
+	case "", repo.OrderByID, repo.OrderByName, repo.OrderByAge, repo.OrderByMail:
+	default:
+		return nil, domain.Errorf(domain.Invalid, "users can't be ordered by %s", order.Field)
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	list := []*api.User{}
+	for _, user := range u.users {
+		if matchUser(filter, user) {
+			list = append(list, copyUser(user))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return lessUser(order, list[i], list[j]) })
+
+	return list, nil
+}
+
+func matchUser(f repo.Filter, user api.User) bool {
+	return (f.ID == nil || user.Id == *f.ID) &&
+		strings.HasPrefix(user.Name, f.NamePrefix) &&
+		(f.MinAge == nil || user.Age >= *f.MinAge) &&
+		(f.MaxAge == nil || user.Age <= *f.MaxAge) &&
+		(f.MailDomain == "" || strings.HasSuffix(user.Mail, "@"+f.MailDomain))
+}
+
+// lessUser : whether a sorts before b, ties are broken by ascending id
+func lessUser(o repo.Order, a, b *api.User) bool {
+	var cmp int
+	switch o.Field {
+	case repo.OrderByName:
+		cmp = strings.Compare(a.Name, b.Name)
+	case repo.OrderByAge:
+		cmp = compareInt(a.Age, b.Age)
+	case repo.OrderByMail:
+		cmp = strings.Compare(a.Mail, b.Mail)
+	default:
+		cmp = compareInt(a.Id, b.Id)
+	}
+	if o.Desc {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return a.Id < b.Id
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (u *userRepository) Update(ctx context.Context, user *api.User, fields []string) (int64, error) {
+	if len(fields) == 0 {
+		return -1, domain.Errorf(domain.Invalid, "no user field to update")
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.users[user.Id]
+	for _, field := range fields {
+		switch field {
+		case repo.FieldName:
+			stored.Name = user.Name
+		case repo.FieldAge:
+			stored.Age = user.Age
+		case repo.FieldMail:
+			stored.Mail = user.Mail
+		case repo.FieldAddress:
+			stored.Address = user.Address
+		default:
+			return -1, domain.Errorf(domain.Invalid, "user field %s can't be updated", field)
+		}
+	}
+	if !ok {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", user.Id)
+	}
+	if err := checkVersion(stored, user.Version); err != nil {
+		return -1, err
+	}
+	stored.Version++
+	u.users[user.Id] = stored
+
+	return 1, nil
+}
+
+func (u *userRepository) Delete(ctx context.Context, id int64, version int64) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.users[id]
+	if !ok {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+	if err := checkVersion(stored, version); err != nil {
+		return -1, err
+	}
+	delete(u.users, id)
+
+	return 1, nil
+}
+
+func (u *userRepository) InsertBatch(ctx context.Context, users []*api.User) ([]int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	ids := make([]int64, 0, len(users))
+	for _, user := range users {
+		u.lastID++
+		u.users[u.lastID] = api.User{
+			Id:      u.lastID,
+			Name:    user.Name,
+			Age:     user.Age,
+			Mail:    user.Mail,
+			Address: user.Address,
+			Version: 1,
+		}
+		ids = append(ids, u.lastID)
+	}
+
+	return ids, nil
+}
+
+func (u *userRepository) SelectByIDs(ctx context.Context, ids []int64) ([]*api.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	list := []*api.User{}
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		if user, ok := u.users[id]; ok && !seen[id] {
+			list = append(list, copyUser(user))
+			seen[id] = true
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+
+	return list, nil
+}
+
+func (u *userRepository) DeleteBatch(ctx context.Context, keys []repo.Key) (int64, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// check every key before deleting any so a failure deletes nothing
+	for _, key := range keys {
+		stored, ok := u.users[key.ID]
+		if !ok {
+			return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", key.ID)
+		}
+		if err := checkVersion(stored, key.Version); err != nil {
+			return -1, err
+		}
+	}
+	for _, key := range keys {
+		delete(u.users, key.ID)
+	}
+
+	return int64(len(keys)), nil
+}
+
+// checkVersion : a Conflict unless version is 0 or the version of stored
+func checkVersion(stored api.User, version int64) error {
+	if version != 0 && version != stored.Version {
+		return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+			stored.Id, stored.Version, version)
+	}
+	return nil
+}
+
+// copyUser : hand out a fresh message so callers cannot mutate the store
+func copyUser(user api.User) *api.User {
+	return &api.User{
+		Id:      user.Id,
+		Name:    user.Name,
+		Age:     user.Age,
+		Mail:    user.Mail,
+		Address: user.Address,
+		Version: user.Version,
+	}
+}
@@ -0,0 +1,148 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/user"
+	userrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+func TestInsert(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		id, err := ur.Insert(ctx, &api.User{Name: "Bob", Age: 11, Mail: "sample@sample.com", Address: "Tokyo"})
+		if err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+		if id != want {
+			t.Errorf("want id %d but actual %d", want, id)
+		}
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+	user := &api.User{Name: "Bob", Age: 11, Mail: "sample@sample.com", Address: "Tokyo"}
+	id, _ := ur.Insert(ctx, user)
+
+	actual, err := ur.SelectByID(ctx, id)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	if actual.Id != id || actual.Name != user.Name || actual.Age != user.Age ||
+		actual.Mail != user.Mail || actual.Address != user.Address {
+		t.Errorf("want %v but actual %v", user, actual)
+	}
+
+	actual.Name = "Alice"
+	if stored, _ := ur.SelectByID(ctx, id); stored.Name != user.Name {
+		t.Errorf("stored user is mutated through returned value: %v", stored)
+	}
+
+	if _, err := ur.SelectByID(ctx, id+1); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+}
+
+var allPage = userrepo.Page{Limit: 100}
+
+func TestSelectAll(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+
+	list, err := ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, allPage)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("want empty list but actual %v, %v", list, err)
+	}
+
+	for _, name := range []string{"Bob", "Alice", "Carol"} {
+		ur.Insert(ctx, &api.User{Name: name})
+	}
+	list, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, allPage)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("want 3 users but actual %d", len(list))
+	}
+	for i, user := range list {
+		if user.Id != int64(i+1) {
+			t.Errorf("want id %d but actual %d", i+1, user.Id)
+		}
+	}
+
+	list, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, userrepo.Page{After: list[0], Limit: 1})
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != 1 || list[0].Id != 2 {
+		t.Errorf("want only id 2 but actual %v", list)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+	id, _ := ur.Insert(ctx, &api.User{Name: "Bob", Age: 11})
+
+	fields := []string{userrepo.FieldName, userrepo.FieldAge}
+
+	rows, err := ur.Update(ctx, &api.User{Id: id, Name: "Alice", Age: 12}, fields)
+	if err != nil || rows != 1 {
+		t.Fatalf("want 1 row updated but actual %d, %v", rows, err)
+	}
+	if user, _ := ur.SelectByID(ctx, id); user.Name != "Alice" || user.Age != 12 {
+		t.Errorf("user is not updated: %v", user)
+	}
+
+	if _, err := ur.Update(ctx, &api.User{Id: id + 1, Name: "Carol"}, fields); err == nil {
+		t.Errorf("error was expected while Update stats")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+	id, _ := ur.Insert(ctx, &api.User{Name: "Bob"})
+
+	rows, err := ur.Delete(ctx, id, 0)
+	if err != nil || rows != 1 {
+		t.Fatalf("want 1 row deleted but actual %d, %v", rows, err)
+	}
+	if _, err := ur.Delete(ctx, id, 0); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+
+	next, _ := ur.Insert(ctx, &api.User{Name: "Alice"})
+	if next == id {
+		t.Errorf("deleted id %d is reused", id)
+	}
+}
+
+func TestConcurrentInsert(t *testing.T) {
+	ur := repo.NewUserRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ur.Insert(ctx, &api.User{Name: "Bob"})
+			ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, allPage)
+		}()
+	}
+	wg.Wait()
+
+	list, _ := ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, allPage)
+	if len(list) != 50 {
+		t.Errorf("want 50 users but actual %d", len(list))
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/account/repository"
+)
+
+const (
+	selectAccounts = "SELECT a.`account_id`, a.`date`, a.`store_id`, d.`item_id` FROM accounts a " + joinDetails
+	// selectAccountPage : the page is cut from accounts before the join, so
+	// that LIMIT counts accounts rather than their detail rows
+	selectAccountPage = "SELECT a.`account_id`, a.`date`, a.`store_id`, d.`item_id` FROM " +
+		"(SELECT `account_id`, `date`, `store_id` FROM accounts WHERE `account_id` > ? ORDER BY `account_id` LIMIT ?) a " +
+		joinDetails
+	joinDetails = "LEFT JOIN account_details d ON d.`account_id` = a.`account_id`"
+)
+
+type accountRepository struct {
+	db *sqlx.DB
+}
+
+// NewAccountRepository : accounts and their details are stored in a parent/child
+// table pair, and every write touching both runs in a single transaction.
+func NewAccountRepository(db *sqlx.DB) repo.AccountRepository {
+	return &accountRepository{
+		db: db,
+	}
+}
+
+func (a *accountRepository) Insert(ctx context.Context, account *api.Account) (int64, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return -1, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO accounts(`date`, `store_id`) VALUES(?, ?)",
+		account.Date, account.StoreId)
+	if err != nil {
+		return -1, dberr.Wrap("failed to insert account", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, dberr.Wrap("failed to retrieve account id", err)
+	}
+
+	if err := insertDetails(ctx, tx, id, account.Details); err != nil {
+		return -1, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, dberr.Wrap("failed to commit account", err)
+	}
+
+	return id, nil
+}
+
+func (a *accountRepository) SelectByID(ctx context.Context, id int64) (*api.Account, error) {
+	rows, err := a.db.QueryxContext(ctx,
+		selectAccounts+" WHERE a.`account_id` = ? ORDER BY d.`id`",
+		id)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select operation", err)
+	}
+	defer rows.Close()
+
+	list, err := scanAccounts(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return list[0], nil
+}
+
+func (a *accountRepository) SelectAll(ctx context.Context, page repo.Page) ([]*api.Account, error) {
+	rows, err := a.db.QueryxContext(ctx,
+		selectAccountPage+" ORDER BY a.`account_id`, d.`id`",
+		page.AfterID, page.Limit)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	return scanAccounts(rows)
+}
+
+func (a *accountRepository) Update(ctx context.Context, account *api.Account) (int64, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return -1, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE accounts SET `date`=?, `store_id`=? WHERE `account_id`=?",
+		account.Date, account.StoreId, account.AccountId)
+	if err != nil {
+		return -1, dberr.Wrap("failed to update account", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", account.AccountId)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM account_details WHERE `account_id`=?", account.AccountId); err != nil {
+		return -1, dberr.Wrap("failed to delete account details", err)
+	}
+
+	if err := insertDetails(ctx, tx, account.AccountId, account.Details); err != nil {
+		return -1, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, dberr.Wrap("failed to commit account", err)
+	}
+
+	return rows, nil
+}
+
+// Delete : account_details rows are removed by the ON DELETE CASCADE foreign key.
+func (a *accountRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	res, err := a.db.ExecContext(ctx, "DELETE FROM accounts WHERE `account_id`= ?", id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return rows, nil
+}
+
+func insertDetails(ctx context.Context, tx *sqlx.Tx, id int64, details []*api.Account_Detail) error {
+	for _, detail := range details {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO account_details(`account_id`, `item_id`) VALUES(?, ?)",
+			id, detail.ItemId); err != nil {
+			return dberr.Wrap("failed to insert account detail", err)
+		}
+	}
+	return nil
+}
+
+// scanAccounts : rows must be ordered by account_id so that the details of
+// one account are adjacent.
+func scanAccounts(rows *sqlx.Rows) ([]*api.Account, error) {
+	list := []*api.Account{}
+	var current *api.Account
+	for rows.Next() {
+		var account api.Account
+		var itemID sql.NullInt64
+		if err := rows.Scan(&account.AccountId, &account.Date, &account.StoreId, &itemID); err != nil {
+			return nil, dberr.Wrap("failed to scan account", err)
+		}
+
+		if current == nil || current.AccountId != account.AccountId {
+			current = &account
+			current.Details = []*api.Account_Detail{}
+			list = append(list, current)
+		}
+		if itemID.Valid {
+			current.Details = append(current.Details, &api.Account_Detail{ItemId: itemID.Int64})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read accounts", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,307 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/account"
+	accountrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/account/repository"
+)
+
+type rowsAffectedError struct{}
+
+func (rae *rowsAffectedError) LastInsertId() (int64, error) {
+	return 1, nil
+}
+func (rae *rowsAffectedError) RowsAffected() (int64, error) {
+	return 0, fmt.Errorf("error")
+}
+
+func testAccount() *api.Account {
+	return &api.Account{
+		AccountId: 1,
+		Date:      "2019-08-01",
+		StoreId:   3,
+		Details: []*api.Account_Detail{
+			&api.Account_Detail{ItemId: 10},
+			&api.Account_Detail{ItemId: 11},
+		},
+	}
+}
+
+func TestInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	account := testAccount()
+	ctx := context.Background()
+
+	if _, err = ar.Insert(ctx, account); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").
+		WithArgs(account.Date, account.StoreId).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO account_details").
+		WithArgs(5, 10).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO account_details").
+		WithArgs(5, 11).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	if id, err := ar.Insert(ctx, account); err != nil || id != 5 {
+		t.Errorf("error was not expected while Insert stats: %d, %s", id, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectExec("INSERT INTO account_details").
+		WillReturnError(fmt.Errorf("error"))
+	mock.ExpectRollback()
+	if _, err = ar.Insert(ctx, account); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO accounts").
+		WillReturnError(fmt.Errorf("error"))
+	mock.ExpectRollback()
+	if _, err = ar.Insert(ctx, account); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+
+	columns := []string{"account_id", "date", "store_id", "item_id"}
+	mock.ExpectQuery("^SELECT (.+) FROM accounts a LEFT JOIN account_details d (.+) WHERE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "2019-08-01", 3, 10).
+			AddRow(1, "2019-08-01", 3, 11))
+	account, err := ar.SelectByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	if len(account.Details) != 2 || account.Details[1].ItemId != 11 {
+		t.Errorf("want 2 details but actual %v", account.Details)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM accounts a LEFT JOIN account_details d (.+) WHERE").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "2019-08-01", 3, nil))
+	account, err = ar.SelectByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	if len(account.Details) != 0 {
+		t.Errorf("want no details but actual %v", account.Details)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM accounts a LEFT JOIN account_details d (.+) WHERE").
+		WillReturnRows(sqlmock.NewRows(columns))
+	if _, err = ar.SelectByID(ctx, 3); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %s but actual %s", domain.NotFound, err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM accounts a LEFT JOIN account_details d (.+) WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "BAD"}).AddRow(1, ""))
+	if _, err = ar.SelectByID(ctx, 1); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+	page := accountrepo.Page{AfterID: 0, Limit: 10}
+
+	if _, err = ar.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM \\(SELECT (.+) FROM accounts WHERE (.+) LIMIT \\?\\) a LEFT JOIN account_details d (.+) ORDER BY").
+		WithArgs(page.AfterID, page.Limit).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "date", "store_id", "item_id"}).
+			AddRow(1, "2019-08-01", 3, 10).
+			AddRow(1, "2019-08-01", 3, 11).
+			AddRow(2, "2019-08-02", 4, nil).
+			AddRow(3, "2019-08-03", 4, 12))
+	list, err := ar.SelectAll(ctx, page)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("want 3 accounts but actual %d", len(list))
+	}
+	if len(list[0].Details) != 2 || len(list[1].Details) != 0 || len(list[2].Details) != 1 {
+		t.Errorf("details are not grouped by account: %v", list)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM \\(SELECT (.+) FROM accounts WHERE (.+) LIMIT \\?\\) a LEFT JOIN account_details d (.+) ORDER BY").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "date", "store_id", "item_id"}).
+			AddRow(1, "2019-08-01", 3, 10).
+			RowError(0, fmt.Errorf("error")))
+	if _, err = ar.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	account := testAccount()
+	ctx := context.Background()
+
+	if _, err = ar.Update(ctx, account); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").
+		WithArgs(account.Date, account.StoreId, account.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM account_details WHERE").
+		WithArgs(account.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("INSERT INTO account_details").
+		WithArgs(account.AccountId, 10).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO account_details").
+		WithArgs(account.AccountId, 11).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	if _, err = ar.Update(ctx, account); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	if _, err = ar.Update(ctx, account); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %s but actual %s", domain.NotFound, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").WillReturnResult(&rowsAffectedError{})
+	mock.ExpectRollback()
+	if _, err = ar.Update(ctx, account); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM account_details WHERE").WillReturnError(fmt.Errorf("error"))
+	mock.ExpectRollback()
+	if _, err = ar.Update(ctx, account); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM account_details WHERE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO account_details").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO account_details").WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("error"))
+	if _, err = ar.Update(ctx, account); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+// TestUpdateDetailsOnly : the header row is matched but left unchanged, which
+// the connection reports as one affected row with clientFoundRows=true, and
+// the details must still be rewritten.
+func TestUpdateDetailsOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	account := testAccount()
+	account.Details = []*api.Account_Detail{&api.Account_Detail{ItemId: 12}}
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE accounts SET").
+		WithArgs(account.Date, account.StoreId, account.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM account_details WHERE").
+		WithArgs(account.AccountId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO account_details").
+		WithArgs(account.AccountId, 12).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+	if rows, err := ar.Update(ctx, account); err != nil || rows != 1 {
+		t.Errorf("want 1 row but actual %d, %v", rows, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ar := repo.NewAccountRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+
+	if _, err = ar.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM accounts WHERE").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err = ar.Delete(ctx, 1); err != nil {
+		t.Errorf("error was not expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM accounts WHERE").WillReturnResult(&rowsAffectedError{})
+	if _, err = ar.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM accounts WHERE").WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err = ar.Delete(ctx, 1); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %s but actual %s", domain.NotFound, err)
+	}
+}
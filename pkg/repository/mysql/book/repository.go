@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/book/repository"
+)
+
+type bookRepository struct {
+	db *sqlx.DB
+}
+
+func NewBookRepository(db *sqlx.DB) repo.BookRepository {
+	return &bookRepository{
+		db: db,
+	}
+}
+
+func (b *bookRepository) Insert(ctx context.Context, book *api.Book) (int64, error) {
+	res, err := b.db.NamedExecContext(ctx,
+		"INSERT INTO books(`title`, `author`, `description`, `pages`, `price`) VALUES(:title, :author, :description, :pages, :price)",
+		book)
+	if err != nil {
+		return -1, dberr.Wrap("failed to insert book", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, dberr.Wrap("failed to retrieve book id", err)
+	}
+
+	return id, nil
+}
+
+func (b *bookRepository) SelectByID(ctx context.Context, id int64) (*api.Book, error) {
+	res, err := b.db.QueryxContext(ctx,
+		"SELECT `id`, `title`, `author`, `description`, `pages`, `price` FROM books WHERE `id` = ?",
+		id)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select operation", err)
+	}
+	defer res.Close()
+
+	if !res.Next() {
+		if err := res.Err(); err != nil {
+			return nil, dberr.Wrap("failed to get data", err)
+		}
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	var book api.Book
+	if err := res.StructScan(&book); err != nil {
+		return nil, dberr.Wrap("failed to scan book", err)
+	}
+
+	return &book, nil
+}
+
+func (b *bookRepository) SelectAll(ctx context.Context, page repo.Page) ([]*api.Book, error) {
+	rows, err := b.db.QueryxContext(ctx,
+		"SELECT `id`, `title`, `author`, `description`, `pages`, `price` FROM books WHERE `id` > ? ORDER BY `id` LIMIT ?",
+		page.AfterID, page.Limit)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	list := []*api.Book{}
+	for rows.Next() {
+		var book api.Book
+		if err := rows.StructScan(&book); err != nil {
+			return nil, dberr.Wrap("failed to scan book", err)
+		}
+		list = append(list, &book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read books", err)
+	}
+
+	return list, nil
+}
+
+func (b *bookRepository) Update(ctx context.Context, book *api.Book) (int64, error) {
+	res, err := b.db.NamedExecContext(ctx,
+		"UPDATE books SET `title`=:title, `author`=:author, `description`=:description, `pages`=:pages, `price`=:price WHERE `id`=:id",
+		book)
+	if err != nil {
+		return -1, dberr.Wrap("failed to update book", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", book.Id)
+	}
+
+	return rows, nil
+}
+
+func (b *bookRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	res, err := b.db.ExecContext(ctx, "DELETE FROM books WHERE `id`= ?", id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,202 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/book"
+	bookrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/book/repository"
+)
+
+type lastInsertIDError struct{}
+
+func (lie *lastInsertIDError) LastInsertId() (int64, error) {
+	return 0, fmt.Errorf("error")
+}
+func (lie *lastInsertIDError) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+type rowsAffectedError struct{}
+
+func (rae *rowsAffectedError) LastInsertId() (int64, error) {
+	return 1, nil
+}
+func (rae *rowsAffectedError) RowsAffected() (int64, error) {
+	return 0, fmt.Errorf("error")
+}
+
+func TestInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	br := repo.NewBookRepository(sqlx.NewDb(db, "sqlmock"))
+
+	book := &api.Book{Title: "Go", Author: "Bob", Description: "gRPC", Pages: 320, Price: 3200}
+
+	ctx := context.Background()
+	if _, err = br.Insert(ctx, book); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	mock.ExpectExec("INSERT INTO books").
+		WithArgs(book.Title, book.Author, book.Description, book.Pages, book.Price).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	if id, err := br.Insert(ctx, book); err != nil || id != 1 {
+		t.Errorf("error was not expected while Insert stats: %d, %s", id, err)
+	}
+
+	mock.ExpectExec("INSERT INTO books").
+		WillReturnResult(&lastInsertIDError{})
+	if _, err = br.Insert(ctx, book); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	br := repo.NewBookRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "pages", "price"}).
+		AddRow(1, "Go", "Bob", "gRPC", 320, 3200)
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE").
+		WithArgs(1).
+		WillReturnRows(rows)
+	if _, err = br.SelectByID(ctx, 1); err != nil {
+		t.Errorf("error was not expected while Select by ID stats: %s", err)
+	}
+
+	if _, err = br.SelectByID(ctx, 2); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "pages", "price"}))
+	if _, err = br.SelectByID(ctx, 3); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "BAD"}).AddRow(1, ""))
+	if _, err = br.SelectByID(ctx, 1); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "BAD"}).RowError(1, fmt.Errorf("error")))
+	if _, err = br.SelectByID(ctx, 1); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	br := repo.NewBookRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+	page := bookrepo.Page{AfterID: 0, Limit: 10}
+
+	if _, err = br.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "title", "author", "description", "pages", "price"}).
+		AddRow(1, "Go", "Bob", "gRPC", 320, 3200).
+		AddRow(2, "Rust", "Alice", "ownership", 480, 4000)
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE (.+) ORDER BY (.+) LIMIT").
+		WithArgs(page.AfterID, page.Limit).
+		WillReturnRows(rows)
+	if list, err := br.SelectAll(ctx, page); err != nil || len(list) != 2 {
+		t.Errorf("error was not expected while Select All stats: %v, %s", list, err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE (.+) ORDER BY (.+) LIMIT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "BAD"}).AddRow(1, "Go"))
+	if _, err = br.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM books WHERE (.+) ORDER BY (.+) LIMIT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author", "description", "pages", "price"}).
+			AddRow(1, "Go", "Bob", "gRPC", 320, 3200).
+			RowError(0, fmt.Errorf("error")))
+	if _, err = br.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	br := repo.NewBookRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+
+	book := &api.Book{Id: 1, Title: "Go", Author: "Bob", Description: "gRPC 2nd", Pages: 360, Price: 3600}
+
+	if _, err = br.Update(ctx, book); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE books SET").
+		WithArgs(book.Title, book.Author, book.Description, book.Pages, book.Price, book.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	if _, err = br.Update(ctx, book); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE books SET").WillReturnResult(&rowsAffectedError{})
+	if _, err = br.Update(ctx, book); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE books SET").WillReturnResult(sqlmock.NewResult(1, 0))
+	if _, err = br.Update(ctx, book); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	br := repo.NewBookRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+
+	if _, err = br.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM books WHERE").WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+	if _, err = br.Delete(ctx, 1); err != nil {
+		t.Errorf("error was not expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM books WHERE").WillReturnResult(&rowsAffectedError{})
+	if _, err = br.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM books WHERE").WillReturnResult(sqlmock.NewResult(1, 0))
+	if _, err = br.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+}
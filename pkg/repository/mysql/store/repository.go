@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+)
+
+type storeRepository struct {
+	db *sqlx.DB
+}
+
+func NewStoreRepository(db *sqlx.DB) repo.StoreRepository {
+	return &storeRepository{
+		db: db,
+	}
+}
+
+func (s *storeRepository) Insert(ctx context.Context, store *api.Store) (int64, error) {
+	res, err := s.db.NamedExecContext(ctx,
+		"INSERT INTO stores(`name`, `mail`, `address`) VALUES(:name, :mail, :address)",
+		store)
+	if err != nil {
+		return -1, dberr.Wrap("failed to insert store", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, dberr.Wrap("failed to retrieve store id", err)
+	}
+
+	return id, nil
+}
+
+func (s *storeRepository) SelectByID(ctx context.Context, id int64) (*api.Store, error) {
+	res, err := s.db.QueryxContext(ctx,
+		"SELECT `id`, `name`, `mail`, `address` FROM stores WHERE `id` = ?",
+		id)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select operation", err)
+	}
+	defer res.Close()
+
+	if !res.Next() {
+		if err := res.Err(); err != nil {
+			return nil, dberr.Wrap("failed to get data", err)
+		}
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	var store api.Store
+	if err := res.StructScan(&store); err != nil {
+		return nil, dberr.Wrap("failed to scan store", err)
+	}
+
+	return &store, nil
+}
+
+func (s *storeRepository) SelectAll(ctx context.Context, page repo.Page) ([]*api.Store, error) {
+	rows, err := s.db.QueryxContext(ctx,
+		"SELECT `id`, `name`, `mail`, `address` FROM stores WHERE `id` > ? ORDER BY `id` LIMIT ?",
+		page.AfterID, page.Limit)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	list := []*api.Store{}
+	for rows.Next() {
+		var store api.Store
+		if err := rows.StructScan(&store); err != nil {
+			return nil, dberr.Wrap("failed to scan store", err)
+		}
+		list = append(list, &store)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read stores", err)
+	}
+
+	return list, nil
+}
+
+func (s *storeRepository) Update(ctx context.Context, store *api.Store) (int64, error) {
+	res, err := s.db.NamedExecContext(ctx,
+		"UPDATE stores SET `name`=:name, `mail`=:mail, `address`=:address WHERE `id`=:id",
+		store)
+	if err != nil {
+		return -1, dberr.Wrap("failed to update store", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", store.Id)
+	}
+
+	return rows, nil
+}
+
+func (s *storeRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM stores WHERE `id`= ?", id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3" // Register SQLite Driver
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user"
+	userrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"github.com/smockoro/grpc-microservice-sample/testdata/contract"
+)
+
+// sqliteUsers : SQLite stand-in for the users table of docker/user-service/mysql
+const sqliteUsers = `CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	age INTEGER NOT NULL,
+	mail TEXT NOT NULL,
+	address TEXT NOT NULL,
+	version INTEGER NOT NULL DEFAULT 1
+)`
+
+func TestContractSQLite(t *testing.T) {
+	contract.TestUserRepository(t, func(t *testing.T) userrepo.UserRepository {
+		db, err := sqlx.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening sqlite", err)
+		}
+		db.SetMaxOpenConns(1) // every connection to :memory: is a new database
+		t.Cleanup(func() { db.Close() })
+
+		if _, err := db.Exec(sqliteUsers); err != nil {
+			t.Fatalf("an error '%s' was not expected when creating users", err)
+		}
+		return repo.NewUserRepository(db)
+	})
+}
+
+func TestContractSQLMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}))
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	contract.MissingUserIsNotFound(t, repo.NewUserRepository(sqlx.NewDb(db, "sqlmock")))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+// sortColumns : allow-list of order fields and the column each one sorts by.
+// order_by never reaches the SQL text unless it is a key of this map.
+var sortColumns = map[string]string{
+	"":               "`id`",
+	repo.OrderByID:   "`id`",
+	repo.OrderByName: "`name`",
+	repo.OrderByAge:  "`age`",
+	repo.OrderByMail: "`mail`",
+}
+
+// listQuery : build the SELECT of a user listing with every value bound as
+// a placeholder. limit 0 selects every matching row.
+func listQuery(f repo.Filter, o repo.Order, after *api.User, limit int) (string, []interface{}, error) {
+	column, ok := sortColumns[o.Field]
+	if !ok {
+		return "", nil, domain.Errorf(domain.Invalid, "users can't be ordered by %s", o.Field)
+	}
+
+	conds := []string{}
+	args := []interface{}{}
+	if f.ID != nil {
+		conds = append(conds, "`id` = ?")
+		args = append(args, *f.ID)
+	}
+	if f.NamePrefix != "" {
+		conds = append(conds, "`name` LIKE ? ESCAPE '!'")
+		args = append(args, escapeLike(f.NamePrefix)+"%")
+	}
+	if f.MinAge != nil {
+		conds = append(conds, "`age` >= ?")
+		args = append(args, *f.MinAge)
+	}
+	if f.MaxAge != nil {
+		conds = append(conds, "`age` <= ?")
+		args = append(args, *f.MaxAge)
+	}
+	if f.MailDomain != "" {
+		conds = append(conds, "`mail` LIKE ? ESCAPE '!'")
+		args = append(args, "%@"+escapeLike(f.MailDomain))
+	}
+
+	cmp, dir := ">", ""
+	if o.Desc {
+		cmp, dir = "<", " DESC"
+	}
+	if after != nil {
+		if column == "`id`" {
+			conds = append(conds, "`id` "+cmp+" ?")
+			args = append(args, after.Id)
+		} else {
+			value := fieldValue(o.Field, after)
+			conds = append(conds, fmt.Sprintf("(%s %s ? OR (%s = ? AND `id` > ?))", column, cmp, column))
+			args = append(args, value, value, after.Id)
+		}
+	}
+
+	query := "SELECT `id`, `name`, `age`, `mail`, `address`, `version` FROM users"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY " + column + dir
+	if column != "`id`" {
+		query += ", `id`"
+	}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	return query, args, nil
+}
+
+// updateColumns : allow-list of fields Update can write and their columns
+var updateColumns = map[string]string{
+	repo.FieldName:    "`name`",
+	repo.FieldAge:     "`age`",
+	repo.FieldMail:    "`mail`",
+	repo.FieldAddress: "`address`",
+}
+
+// updateQuery : build the UPDATE of the given fields of user, matching
+// user.Version as well unless it is 0
+func updateQuery(user *api.User, fields []string) (string, []interface{}, error) {
+	sets := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	for _, field := range fields {
+		column, ok := updateColumns[field]
+		if !ok {
+			return "", nil, domain.Errorf(domain.Invalid, "user field %s can't be updated", field)
+		}
+		sets = append(sets, column+"=?")
+		args = append(args, fieldValue(field, user))
+	}
+	if len(sets) == 0 {
+		return "", nil, domain.Errorf(domain.Invalid, "no user field to update")
+	}
+	sets = append(sets, "`version`=`version`+1")
+
+	query := "UPDATE users SET " + strings.Join(sets, ", ") + " WHERE `id`=?"
+	args = append(args, user.Id)
+	if user.Version != 0 {
+		query += " AND `version`=?"
+		args = append(args, user.Version)
+	}
+
+	return query, args, nil
+}
+
+// deleteQuery : build one DELETE of the rows of keys. Keys with a version
+// match it as well, the others are matched by IN.
+func deleteQuery(keys []repo.Key) (string, []interface{}) {
+	ids := []interface{}{}
+	conds := []string{}
+	args := []interface{}{}
+	for _, key := range keys {
+		if key.Version == 0 {
+			ids = append(ids, key.ID)
+			continue
+		}
+		conds = append(conds, "(`id`=? AND `version`=?)")
+		args = append(args, key.ID, key.Version)
+	}
+	if len(ids) > 0 {
+		conds = append([]string{"`id` IN (" + placeholders(len(ids)) + ")"}, conds...)
+		args = append(ids, args...)
+	}
+
+	return "DELETE FROM users WHERE " + strings.Join(conds, " OR "), args
+}
+
+// placeholders : n comma separated placeholders of an IN list
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func fieldValue(field string, user *api.User) interface{} {
+	switch field {
+	case repo.FieldName:
+		return user.Name
+	case repo.FieldAge:
+		return user.Age
+	case repo.FieldMail:
+		return user.Mail
+	case repo.FieldAddress:
+		return user.Address
+	default:
+		return user.Id
+	}
+}
+
+// escapeLike : make s match literally inside a LIKE pattern with ESCAPE '!'
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
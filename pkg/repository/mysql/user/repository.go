@@ -2,13 +2,13 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
 	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type userRepository struct {
@@ -26,12 +26,12 @@ func (u *userRepository) Insert(ctx context.Context, user *api.User) (int64, err
 		"INSERT INTO users(`name`, `age`, `mail`, `address`) VALUES(:name, :age, :mail, :address)",
 		user)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to insert user"+err.Error())
+		return -1, dberr.Wrap("failed to insert user", err)
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to retrieve user id"+err.Error())
+		return -1, dberr.Wrap("failed to retrieve user id", err)
 	}
 
 	return id, nil
@@ -39,33 +39,38 @@ func (u *userRepository) Insert(ctx context.Context, user *api.User) (int64, err
 
 func (u *userRepository) SelectByID(ctx context.Context, id int64) (*api.User, error) {
 	res, err := u.db.QueryxContext(ctx,
-		"SELECT `id`, `name`, `age`, `mail`, `address` FROM users WHERE `id` = ?",
+		"SELECT `id`, `name`, `age`, `mail`, `address`, `version` FROM users WHERE `id` = ?",
 		id)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select operation"+err.Error())
+		return nil, dberr.Wrap("failed to select operation", err)
 	}
 	defer res.Close()
 
 	if !res.Next() {
 		if err := res.Err(); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to get data "+err.Error())
+			return nil, dberr.Wrap("failed to get data", err)
 		}
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ID='%d' is not found",
-			id))
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
 	}
 
 	var user api.User
 	if err := res.StructScan(&user); err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+		return nil, dberr.Wrap("failed to scan user", err)
 	}
 
 	return &user, nil
 }
 
-func (u *userRepository) SelectAll(ctx context.Context) ([]*api.User, error) {
-	rows, err := u.db.QueryxContext(ctx, "SELECT `id`, `name`, `age`, `mail`, `address` FROM users")
+func (u *userRepository) SelectAll(ctx context.Context, filter repo.Filter, order repo.Order,
+	page repo.Page) ([]*api.User, error) {
+	query, args, err := listQuery(filter, order, page.After, page.Limit)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select "+err.Error())
+		return nil, err
+	}
+
+	rows, err := u.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
 	}
 	defer rows.Close()
 
@@ -73,54 +78,277 @@ func (u *userRepository) SelectAll(ctx context.Context) ([]*api.User, error) {
 	for rows.Next() {
 		var user api.User
 		if err := rows.StructScan(&user); err != nil {
-			return nil, status.Error(codes.Unknown, err.Error())
+			return nil, dberr.Wrap("failed to scan user", err)
 		}
 		list = append(list, &user)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+		return nil, dberr.Wrap("failed to read users", err)
 	}
 
 	return list, nil
 }
 
-func (u *userRepository) Update(ctx context.Context, user *api.User) (int64, error) {
-	res, err := u.db.NamedExecContext(ctx,
-		"UPDATE users SET `name`=:name, `age`=:age, `mail`=:mail, `address`=:address WHERE `id`=:id",
-		user)
+func (u *userRepository) SelectEach(ctx context.Context, filter repo.Filter, order repo.Order,
+	fn func(*api.User) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	query, args, err := listQuery(filter, order, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	rows, err := u.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var user api.User
+		if err := rows.StructScan(&user); err != nil {
+			return dberr.Wrap("failed to scan user", err)
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return dberr.Wrap("failed to read users", err)
+	}
+
+	return nil
+}
+
+func (u *userRepository) Update(ctx context.Context, user *api.User, fields []string) (int64, error) {
+	query, args, err := updateQuery(user, fields)
+	if err != nil {
+		return -1, err
+	}
+
+	res, err := u.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to update user"+err.Error())
+		return -1, dberr.Wrap("failed to update user", err)
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return -1, status.Error(codes.Unknown, err.Error())
+		return -1, dberr.Wrap("failed to count affected rows", err)
 	}
 
 	if rows == 0 {
-		return -1, status.Error(codes.Unknown,
-			fmt.Sprintf("user id %d is not found", user.Id))
+		return -1, u.missing(ctx, user.Id, user.Version)
 	}
 
 	return rows, nil
 }
 
-func (u *userRepository) Delete(ctx context.Context, id int64) (int64, error) {
-	res, err := u.db.ExecContext(ctx, "DELETE FROM users WHERE `id`= ?", id)
+func (u *userRepository) Delete(ctx context.Context, id int64, version int64) (int64, error) {
+	query, args := "DELETE FROM users WHERE `id`= ?", []interface{}{id}
+	if version != 0 {
+		query += " AND `version`= ?"
+		args = append(args, version)
+	}
+
+	res, err := u.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to delete "+err.Error())
+		return -1, dberr.Wrap("failed to delete", err)
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return -1, status.Error(codes.Unknown, err.Error())
+		return -1, dberr.Wrap("failed to count affected rows", err)
 	}
 
 	if rows == 0 {
-		return -1, status.Error(codes.NotFound, fmt.Sprintf("ID='%d' is not found",
-			id))
+		return -1, u.missing(ctx, id, version)
+	}
+
+	return rows, nil
+}
+
+func (u *userRepository) InsertBatch(ctx context.Context, users []*api.User) ([]int64, error) {
+	if len(users) == 0 {
+		return []int64{}, nil
+	}
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	// rows are inserted one by one, as the ids of a multi-row INSERT are
+	// only consecutive with auto_increment_increment=1
+	stmt, err := tx.PrepareContext(ctx,
+		"INSERT INTO users(`name`, `age`, `mail`, `address`) VALUES(?, ?, ?, ?)")
+	if err != nil {
+		return nil, dberr.Wrap("failed to prepare user insert", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]int64, 0, len(users))
+	for _, user := range users {
+		res, err := stmt.ExecContext(ctx, user.Name, user.Age, user.Mail, user.Address)
+		if err != nil {
+			return nil, dberr.Wrap("failed to insert users", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, dberr.Wrap("failed to retrieve user id", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dberr.Wrap("failed to commit users", err)
+	}
+
+	return ids, nil
+}
+
+func (u *userRepository) SelectByIDs(ctx context.Context, ids []int64) ([]*api.User, error) {
+	list := []*api.User{}
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT `id`, `name`, `age`, `mail`, `address`, `version` FROM users WHERE `id` IN (?) ORDER BY `id`",
+		ids)
+	if err != nil {
+		return nil, dberr.Wrap("failed to build query", err)
+	}
+
+	rows, err := u.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user api.User
+		if err := rows.StructScan(&user); err != nil {
+			return nil, dberr.Wrap("failed to scan user", err)
+		}
+		list = append(list, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read users", err)
+	}
+
+	return list, nil
+}
+
+func (u *userRepository) DeleteBatch(ctx context.Context, keys []repo.Key) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return -1, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	query, args := deleteQuery(keys)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows != int64(len(keys)) {
+		// look at the rows as they are without the partial delete
+		if err := tx.Rollback(); err != nil {
+			return -1, dberr.Wrap("failed to roll back delete", err)
+		}
+		return -1, missingKey(ctx, u.db, keys)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, dberr.Wrap("failed to commit delete", err)
 	}
 
 	return rows, nil
 }
+
+// missingKey : the error of a DeleteBatch that matched fewer rows than
+// keys, like missing for the first key that is gone or has moved on
+func missingKey(ctx context.Context, db *sqlx.DB, keys []repo.Key) error {
+	ids := make([]int64, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+	query, args, err := sqlx.In("SELECT `id`, `version` FROM users WHERE `id` IN (?)", ids)
+	if err != nil {
+		return dberr.Wrap("failed to build query", err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return dberr.Wrap("failed to select version", err)
+	}
+	defer rows.Close()
+
+	versions := map[int64]int64{}
+	for rows.Next() {
+		var id, version int64
+		if err := rows.Scan(&id, &version); err != nil {
+			return dberr.Wrap("failed to scan user", err)
+		}
+		versions[id] = version
+	}
+	if err := rows.Err(); err != nil {
+		return dberr.Wrap("failed to read users", err)
+	}
+
+	for _, key := range keys {
+		actual, ok := versions[key.ID]
+		if !ok {
+			return domain.Errorf(domain.NotFound, "ID='%d' is not found", key.ID)
+		}
+		if key.Version != 0 && key.Version != actual {
+			return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+				key.ID, actual, key.Version)
+		}
+	}
+
+	return domain.Errorf(domain.Conflict, "users are changed while deleting")
+}
+
+// missing : the error of a write that matched no row. It is NotFound when
+// the user is gone and Conflict when only its version has moved on.
+func (u *userRepository) missing(ctx context.Context, id int64, version int64) error {
+	notFound := domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	if version == 0 {
+		return notFound
+	}
+
+	var actual int64
+	err := u.db.QueryRowxContext(ctx, "SELECT `version` FROM users WHERE `id` = ?", id).Scan(&actual)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
+	if err != nil {
+		return dberr.Wrap("failed to select version", err)
+	}
+
+	return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+		id, actual, version)
+}
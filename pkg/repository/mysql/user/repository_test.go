@@ -3,12 +3,15 @@ package repository_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
 	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user"
+	userrepo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
 )
 
 type lastInsertIdError struct{}
@@ -116,39 +119,61 @@ func TestSelectAll(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 
 	ur := repo.NewUserRepository(sqlxDB)
+	page := userrepo.Page{After: &api.User{Id: 2}, Limit: 10}
 
 	ctx := context.Background()
-	if _, err = ur.SelectAll(ctx); err == nil {
+	if _, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, page); err == nil {
 		t.Errorf("error was expected while Select All stats: %s", err)
 	}
 
 	rows := sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}).
 		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo").
 		AddRow(2, "Alice", 13, "example@sample.com", "London")
-	mock.ExpectQuery("^SELECT (.+) FROM users$").
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE (.+) ORDER BY (.+) LIMIT").
+		WithArgs(page.After.Id, page.Limit).
 		WillReturnRows(rows)
 	ctx = context.Background()
-	if _, err = ur.SelectAll(ctx); err != nil {
+	if _, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, page); err != nil {
 		t.Errorf("error was not expected while Select All stats: %s", err)
 	}
 
+	minAge := int64(12)
+	filter := userrepo.Filter{NamePrefix: "B%", MinAge: &minAge, MailDomain: "sample.com"}
+	order := userrepo.Order{Field: userrepo.OrderByAge, Desc: true}
+	rows = sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}).
+		AddRow(3, "B%ob", 12, "b@sample.com", "Tokyo")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name`, `age`, `mail`, `address`, `version` FROM users "+
+		"WHERE `name` LIKE ? ESCAPE '!' AND `age` >= ? AND `mail` LIKE ? ESCAPE '!' "+
+		"AND (`age` < ? OR (`age` = ? AND `id` > ?)) ORDER BY `age` DESC, `id` LIMIT ?")).
+		WithArgs("B!%%", minAge, "%@sample.com", int64(13), int64(13), int64(2), 10).
+		WillReturnRows(rows)
+	after := userrepo.Page{After: &api.User{Id: 2, Age: 13}, Limit: 10}
+	if _, err = ur.SelectAll(ctx, filter, order, after); err != nil {
+		t.Errorf("error was not expected while Select All stats: %s", err)
+	}
+
+	order = userrepo.Order{Field: "address"}
+	if _, err = ur.SelectAll(ctx, userrepo.Filter{}, order, page); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+
 	rows = sqlmock.NewRows([]string{"id", "BAD"}).
 		AddRow(1, "Bob").
 		AddRow(2, "Alice")
-	mock.ExpectQuery("^SELECT (.+) FROM users$").
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE (.+) ORDER BY (.+) LIMIT").
 		WillReturnRows(rows)
 	ctx = context.Background()
-	if _, err = ur.SelectAll(ctx); err == nil {
+	if _, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, page); err == nil {
 		t.Errorf("error was expected while Select All stats: %s", err)
 	}
 
 	rows = sqlmock.NewRows([]string{"id", "BAD"}).
 		AddRow(1, "Bob").
 		RowError(1, fmt.Errorf("error"))
-	mock.ExpectQuery("^SELECT (.+) FROM users$").
+	mock.ExpectQuery("^SELECT (.+) FROM users WHERE (.+) ORDER BY (.+) LIMIT").
 		WillReturnRows(rows)
 	ctx = context.Background()
-	if _, err = ur.SelectAll(ctx); err == nil {
+	if _, err = ur.SelectAll(ctx, userrepo.Filter{}, userrepo.Order{}, page); err == nil {
 		t.Errorf("error was expected while Select All stats: %s", err)
 	}
 }
@@ -171,30 +196,44 @@ func TestUpdate(t *testing.T) {
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 
 	user := &api.User{Id: 1, Name: "Bob Olimar", Age: 88, Mail: "aa@sample.com", Address: "Tokyo"}
+	fields := []string{userrepo.FieldName, userrepo.FieldAge, userrepo.FieldMail, userrepo.FieldAddress}
 	ur := repo.NewUserRepository(sqlxDB)
 
 	ctx := context.Background()
-	if _, err = ur.Update(ctx, user); err == nil {
+	if _, err = ur.Update(ctx, user, fields); err == nil {
 		t.Errorf("error was not expected while Update stats: %s", err)
 	}
 
 	mock.ExpectExec("UPDATE users SET").WillReturnResult(sqlmock.NewResult(1, 1))
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, user); err != nil {
+	if _, err = ur.Update(ctx, user, fields); err != nil {
 		t.Errorf("error was not expected while Update stats: %s", err)
 	}
 
 	mock.ExpectExec("UPDATE users SET").WillReturnResult(&rowsAffectedError{})
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, user); err == nil {
+	if _, err = ur.Update(ctx, user, fields); err == nil {
 		t.Errorf("error was not expected while Update stats: %s", err)
 	}
 
 	mock.ExpectExec("UPDATE users SET").WillReturnResult(sqlmock.NewResult(1, 0))
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, user); err == nil {
+	if _, err = ur.Update(ctx, user, fields); err == nil {
 		t.Errorf("error was not expected while Update stats: %s", err)
 	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET `mail`=?, `address`=?, `version`=`version`+1 WHERE `id`=?")).
+		WithArgs(user.Mail, user.Address, user.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	fields = []string{userrepo.FieldMail, userrepo.FieldAddress}
+	if _, err = ur.Update(ctx, user, fields); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	fields = []string{"id"}
+	if _, err = ur.Update(ctx, user, fields); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
 }
 
 func TestDelete(t *testing.T) {
@@ -208,25 +247,155 @@ func TestDelete(t *testing.T) {
 	ur := repo.NewUserRepository(sqlxDB)
 
 	ctx := context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was not expected while Delete stats: %s", err)
 	}
 
 	mock.ExpectExec("DELETE FROM users WHERE").WillReturnResult(sqlmock.NewResult(1, 1))
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err != nil {
+	if _, err = ur.Delete(ctx, 1, 0); err != nil {
 		t.Errorf("error was not expected while Delete stats: %s", err)
 	}
 
 	mock.ExpectExec("DELETE FROM users WHERE").WillReturnResult(&rowsAffectedError{})
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was not expected while Delete stats: %s", err)
 	}
 
 	mock.ExpectExec("DELETE FROM users WHERE").WillReturnResult(sqlmock.NewResult(1, 0))
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was not expected while Delete stats: %s", err)
 	}
 }
+
+func TestInsertBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := repo.NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+	users := []*api.User{
+		{Name: "Bob", Age: 11, Mail: "bob@sample.com", Address: "Tokyo"},
+		{Name: "Alice", Age: 12, Mail: "alice@sample.com", Address: "Osaka"},
+	}
+	insert := regexp.QuoteMeta("INSERT INTO users(`name`, `age`, `mail`, `address`) VALUES(?, ?, ?, ?)")
+
+	// ids are not consecutive when auto_increment_increment is not 1
+	mock.ExpectBegin()
+	stmt := mock.ExpectPrepare(insert)
+	stmt.ExpectExec().
+		WithArgs("Bob", 11, "bob@sample.com", "Tokyo").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	stmt.ExpectExec().
+		WithArgs("Alice", 12, "alice@sample.com", "Osaka").
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectCommit()
+	ids, err := ur.InsertBatch(ctx, users)
+	if err != nil || len(ids) != 2 || ids[0] != 7 || ids[1] != 10 {
+		t.Errorf("want ids [7 10] but actual %v, %v", ids, err)
+	}
+
+	mock.ExpectBegin()
+	stmt = mock.ExpectPrepare(insert)
+	stmt.ExpectExec().WillReturnResult(sqlmock.NewResult(11, 1))
+	stmt.ExpectExec().WillReturnError(fmt.Errorf("some error"))
+	mock.ExpectRollback()
+	if _, err := ur.InsertBatch(ctx, users); err == nil {
+		t.Errorf("error was expected while Insert Batch stats")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := repo.NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+	keys := []userrepo.Key{{ID: 1}, {ID: 2, Version: 3}, {ID: 4}}
+	del := regexp.QuoteMeta("DELETE FROM users WHERE `id` IN (?, ?) OR (`id`=? AND `version`=?)")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(del).WithArgs(1, 4, 2, 3).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	if rows, err := ur.DeleteBatch(ctx, keys); err != nil || rows != 3 {
+		t.Errorf("want 3 rows deleted but actual %d, %v", rows, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(del).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectRollback()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `version` FROM users WHERE `id` IN (?, ?, ?)")).
+		WithArgs(1, 2, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(1, 1).AddRow(2, 4).AddRow(4, 1))
+	if _, err := ur.DeleteBatch(ctx, keys); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSelectEach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := repo.NewUserRepository(sqlx.NewDb(db, "sqlmock"))
+	ctx := context.Background()
+	count := func(n *int) func(*api.User) error {
+		return func(*api.User) error { *n++; return nil }
+	}
+
+	var n int
+	if err = ur.SelectEach(ctx, userrepo.Filter{}, userrepo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo").
+		AddRow(2, "Alice", 13, "example@sample.com", "London")
+	mock.ExpectQuery("^SELECT (.+) FROM users ORDER BY").
+		WillReturnRows(rows)
+	n = 0
+	if err = ur.SelectEach(ctx, userrepo.Filter{}, userrepo.Order{}, count(&n)); err != nil || n != 2 {
+		t.Errorf("want 2 users visited but actual %d, %v", n, err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "BAD"}).
+		AddRow(1, "Bob")
+	mock.ExpectQuery("^SELECT (.+) FROM users ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, userrepo.Filter{}, userrepo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo").
+		RowError(0, fmt.Errorf("error"))
+	mock.ExpectQuery("^SELECT (.+) FROM users ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, userrepo.Filter{}, userrepo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo")
+	mock.ExpectQuery("^SELECT (.+) FROM users ORDER BY").
+		WillReturnRows(rows)
+	stop := fmt.Errorf("stop")
+	if err = ur.SelectEach(ctx, userrepo.Filter{}, userrepo.Order{}, func(*api.User) error { return stop }); err != stop {
+		t.Errorf("want %v but actual %v", stop, err)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+)
+
+func TestListQuery(t *testing.T) {
+	price := int64(100)
+	cases := []struct {
+		name  string
+		f     repo.Filter
+		o     repo.Order
+		after *api.Item
+		limit int
+		query string
+		args  []interface{}
+	}{
+		{name: "everything",
+			query: "SELECT id, name, description, price, version FROM itemschema.items ORDER BY id",
+			args:  []interface{}{}},
+		{name: "next page by id desc", o: repo.Order{Field: repo.OrderByID, Desc: true},
+			after: &api.Item{Id: 5}, limit: 10,
+			query: "SELECT id, name, description, price, version FROM itemschema.items WHERE id < $1 " +
+				"ORDER BY id DESC LIMIT $2",
+			args: []interface{}{int64(5), 10}},
+		{name: "filtered next page by price desc",
+			f:     repo.Filter{NamePrefix: "Pen", MinPrice: &price, MaxPrice: &price},
+			o:     repo.Order{Field: repo.OrderByPrice, Desc: true},
+			after: &api.Item{Id: 3, Price: 100}, limit: 2,
+			query: "SELECT id, name, description, price, version FROM itemschema.items WHERE name LIKE $1 ESCAPE '!' " +
+				"AND price >= $2 AND price <= $3 AND (price < $4 OR (price = $5 AND id > $6)) " +
+				"ORDER BY price DESC, id LIMIT $7",
+			args: []interface{}{"Pen%", price, price, int64(100), int64(100), int64(3), 2}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			query, args, err := listQuery(c.f, c.o, c.after, c.limit)
+			if err != nil {
+				t.Fatalf("error was not expected: %s", err)
+			}
+			if query != c.query {
+				t.Errorf("want %q but actual %q", c.query, query)
+			}
+			if !reflect.DeepEqual(args, c.args) {
+				t.Errorf("want %#v but actual %#v", c.args, args)
+			}
+		})
+	}
+
+	if _, _, err := listQuery(repo.Filter{}, repo.Order{Field: "description"}, nil, 0); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+}
+
+func TestDeleteQuery(t *testing.T) {
+	query, args := deleteQuery([]repo.Key{{ID: 1}, {ID: 2, Version: 3}, {ID: 4}})
+	want := "DELETE FROM itemschema.items WHERE id IN ($1, $2) OR (id=$3 AND version=$4)"
+	if query != want {
+		t.Errorf("want %s but actual %s", want, query)
+	}
+	if wantArgs := []interface{}{int64(1), int64(4), int64(2), int64(3)}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("want %v but actual %v", wantArgs, args)
+	}
+
+	query, _ = deleteQuery([]repo.Key{{ID: 2, Version: 3}})
+	if want := "DELETE FROM itemschema.items WHERE (id=$1 AND version=$2)"; query != want {
+		t.Errorf("want %s but actual %s", want, query)
+	}
+}
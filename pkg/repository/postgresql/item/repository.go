@@ -3,12 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"sort"
 
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
 	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type itemRepository struct {
@@ -22,7 +22,7 @@ func NewItemRepository(db *sql.DB) repo.ItemRepository {
 func (u *itemRepository) connect(ctx context.Context) (*sql.Conn, error) {
 	c, err := u.db.Conn(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "Item Database Connect Error"+err.Error())
+		return nil, dberr.Wrap("failed to connect to item database", err)
 	}
 	return c, nil
 }
@@ -34,16 +34,12 @@ func (u *itemRepository) Insert(ctx context.Context, item *api.Item) (int64, err
 	}
 	defer c.Close()
 
-	res, err := c.ExecContext(ctx,
-		"INSERT INTO items(`name`, `description`, `price`) VALUES(?, ?, ?)",
-		item.Name, item.Description, item.Price)
+	var id int64
+	err = c.QueryRowContext(ctx,
+		"INSERT INTO itemschema.items(name, description, price) VALUES($1, $2, $3) RETURNING id",
+		item.Name, item.Description, item.Price).Scan(&id)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to insert item"+err.Error())
-	}
-
-	id, err := res.LastInsertId()
-	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to retrieve item id"+err.Error())
+		return -1, dberr.Wrap("failed to insert item", err)
 	}
 
 	return id, nil
@@ -57,24 +53,23 @@ func (u *itemRepository) SelectByID(ctx context.Context, id int64) (*api.Item, e
 	defer c.Close()
 
 	res, err := c.QueryContext(ctx,
-		"SELECT `id`, `name`, `description`, `price` FROM items WHERE `id` = ?",
+		"SELECT id, name, description, price, version FROM itemschema.items WHERE id = $1",
 		id)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select operation"+err.Error())
+		return nil, dberr.Wrap("failed to select operation", err)
 	}
 	defer res.Close()
 
 	if !res.Next() {
 		if err := res.Err(); err != nil {
-			return nil, status.Error(codes.Unknown, "failed to get data "+err.Error())
+			return nil, dberr.Wrap("failed to get data", err)
 		}
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("ID='%d' is not found",
-			id))
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
 	}
 
 	var item api.Item
-	if err := res.Scan(&item.Id, &item.Name, &item.Description, &item.Price); err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+	if err := res.Scan(&item.Id, &item.Name, &item.Description, &item.Price, &item.Version); err != nil {
+		return nil, dberr.Wrap("failed to scan item", err)
 	}
 
 	return &api.Item{
@@ -82,86 +77,332 @@ func (u *itemRepository) SelectByID(ctx context.Context, id int64) (*api.Item, e
 		Name:        item.Name,
 		Description: item.Description,
 		Price:       item.Price,
+		Version:     item.Version,
 	}, nil
 }
 
-func (u *itemRepository) SelectAll(ctx context.Context) ([]*api.Item, error) {
+func (u *itemRepository) SelectAll(ctx context.Context, filter repo.Filter, order repo.Order,
+	page repo.Page) ([]*api.Item, error) {
+	query, args, err := listQuery(filter, order, page.After, page.Limit)
+	if err != nil {
+		return nil, err
+	}
+
 	c, err := u.connect(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer c.Close()
 
-	rows, err := c.QueryContext(ctx, "SELECT `id`, `name`, `description`, `price` FROM items")
+	rows, err := c.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, status.Error(codes.Unknown, "failed to select "+err.Error())
+		return nil, dberr.Wrap("failed to select", err)
 	}
 	defer rows.Close()
 
 	list := []*api.Item{}
 	for rows.Next() {
 		item := new(api.Item)
-		if err := rows.Scan(&item.Id, &item.Name, &item.Description, &item.Price); err != nil {
-			return nil, status.Error(codes.Unknown, err.Error())
+		if err := rows.Scan(&item.Id, &item.Name, &item.Description, &item.Price, &item.Version); err != nil {
+			return nil, dberr.Wrap("failed to scan item", err)
 		}
 		list = append(list, item)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+		return nil, dberr.Wrap("failed to read items", err)
 	}
 
 	return list, nil
 }
 
-func (u *itemRepository) Update(ctx context.Context, item *api.Item) (int64, error) {
+func (u *itemRepository) SelectEach(ctx context.Context, filter repo.Filter, order repo.Order,
+	fn func(*api.Item) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	query, args, err := listQuery(filter, order, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	c, err := u.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	rows, err := c.QueryContext(ctx, query, args...)
+	if err != nil {
+		return dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		item := new(api.Item)
+		if err := rows.Scan(&item.Id, &item.Name, &item.Description, &item.Price, &item.Version); err != nil {
+			return dberr.Wrap("failed to scan item", err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return dberr.Wrap("failed to read items", err)
+	}
+
+	return nil
+}
+
+func (u *itemRepository) Update(ctx context.Context, item *api.Item, fields []string) (int64, error) {
+	query, args, err := updateQuery(item, fields)
+	if err != nil {
+		return -1, err
+	}
+
 	c, err := u.connect(ctx)
 	if err != nil {
 		return -1, err
 	}
 	defer c.Close()
 
-	res, err := c.ExecContext(ctx,
-		"UPDATE items SET `name`=?, `description`=?, `price`=? WHERE `id`=?",
-		item.Name, item.Description, item.Price, item.Id)
+	res, err := c.ExecContext(ctx, query, args...)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to update item"+err.Error())
+		return -1, dberr.Wrap("failed to update item", err)
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return -1, status.Error(codes.Unknown, err.Error())
+		return -1, dberr.Wrap("failed to count affected rows", err)
 	}
 
 	if rows == 0 {
-		return -1, status.Error(codes.Unknown,
-			fmt.Sprintf("item id %d is not found", item.Id))
+		return -1, u.missing(ctx, c, item.Id, item.Version)
 	}
 
 	return rows, nil
 }
 
-func (u *itemRepository) Delete(ctx context.Context, id int64) (int64, error) {
+func (u *itemRepository) Delete(ctx context.Context, id int64, version int64) (int64, error) {
 	c, err := u.connect(ctx)
 	if err != nil {
 		return -1, err
 	}
 	defer c.Close()
 
-	res, err := c.ExecContext(ctx, "DELETE FROM items WHERE `id`=?", id)
+	query, args := "DELETE FROM itemschema.items WHERE id=$1", []interface{}{id}
+	if version != 0 {
+		query += " AND version=$2"
+		args = append(args, version)
+	}
+
+	res, err := c.ExecContext(ctx, query, args...)
 	if err != nil {
-		return -1, status.Error(codes.Unknown, "failed to delete "+err.Error())
+		return -1, dberr.Wrap("failed to delete", err)
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return -1, status.Error(codes.Unknown, err.Error())
+		return -1, dberr.Wrap("failed to count affected rows", err)
 	}
 
 	if rows == 0 {
-		return -1, status.Error(codes.NotFound, fmt.Sprintf("ID='%d' is not found",
-			id))
+		return -1, u.missing(ctx, c, id, version)
 	}
 
 	return rows, nil
 }
+
+func (u *itemRepository) InsertBatch(ctx context.Context, items []*api.Item) ([]int64, error) {
+	ids := []int64{}
+	if len(items) == 0 {
+		return ids, nil
+	}
+
+	c, err := u.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	query, args := insertQuery(items)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, dberr.Wrap("failed to insert items", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, dberr.Wrap("failed to scan item", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to insert items", err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, dberr.Wrap("failed to commit items", err)
+	}
+
+	// the sequence numbers the rows in VALUES order, RETURNING has no order
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids, nil
+}
+
+func (u *itemRepository) SelectByIDs(ctx context.Context, ids []int64) ([]*api.Item, error) {
+	list := []*api.Item{}
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	c, err := u.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	query, args := selectByIDsQuery(ids)
+	rows, err := c.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(api.Item)
+		if err := rows.Scan(&item.Id, &item.Name, &item.Description, &item.Price, &item.Version); err != nil {
+			return nil, dberr.Wrap("failed to scan item", err)
+		}
+		list = append(list, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read items", err)
+	}
+
+	return list, nil
+}
+
+func (u *itemRepository) DeleteBatch(ctx context.Context, keys []repo.Key) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	c, err := u.connect(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer c.Close()
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, dberr.Wrap("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+
+	query, args := deleteQuery(keys)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows != int64(len(keys)) {
+		// look at the rows as they are without the partial delete
+		if err := tx.Rollback(); err != nil {
+			return -1, dberr.Wrap("failed to roll back delete", err)
+		}
+		return -1, missingKey(ctx, c, keys)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, dberr.Wrap("failed to commit delete", err)
+	}
+
+	return rows, nil
+}
+
+// missingKey : the error of a DeleteBatch that matched fewer rows than
+// keys, like missing for the first key that is gone or has moved on
+func missingKey(ctx context.Context, c *sql.Conn, keys []repo.Key) error {
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key.ID
+	}
+
+	rows, err := c.QueryContext(ctx,
+		"SELECT id, version FROM itemschema.items WHERE id IN ("+placeholders(1, len(keys))+")", args...)
+	if err != nil {
+		return dberr.Wrap("failed to select version", err)
+	}
+	defer rows.Close()
+
+	versions := map[int64]int64{}
+	for rows.Next() {
+		var id, version int64
+		if err := rows.Scan(&id, &version); err != nil {
+			return dberr.Wrap("failed to scan item", err)
+		}
+		versions[id] = version
+	}
+	if err := rows.Err(); err != nil {
+		return dberr.Wrap("failed to read items", err)
+	}
+
+	for _, key := range keys {
+		actual, ok := versions[key.ID]
+		if !ok {
+			return domain.Errorf(domain.NotFound, "ID='%d' is not found", key.ID)
+		}
+		if key.Version != 0 && key.Version != actual {
+			return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+				key.ID, actual, key.Version)
+		}
+	}
+
+	return domain.Errorf(domain.Conflict, "items are changed while deleting")
+}
+
+// missing : the error of a write that matched no row. It is NotFound when
+// the item is gone and Conflict when only its version has moved on.
+func (u *itemRepository) missing(ctx context.Context, c *sql.Conn, id int64, version int64) error {
+	notFound := domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	if version == 0 {
+		return notFound
+	}
+
+	var actual int64
+	err := c.QueryRowContext(ctx, "SELECT version FROM itemschema.items WHERE id = $1", id).Scan(&actual)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
+	if err != nil {
+		return dberr.Wrap("failed to select version", err)
+	}
+
+	return domain.Errorf(domain.Conflict, "ID='%d' has version %d, not %d",
+		id, actual, version)
+}
@@ -3,22 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
 )
 
-type lastInsertIDError struct{}
-
-func (lie *lastInsertIDError) LastInsertId() (int64, error) {
-	return 0, fmt.Errorf("error")
-}
-func (lie *lastInsertIDError) RowsAffected() (int64, error) {
-	return 1, nil
-}
-
 type rowsAffectedError struct{}
 
 func (rae *rowsAffectedError) LastInsertId() (int64, error) {
@@ -49,17 +43,17 @@ func TestInsert(t *testing.T) {
 		t.Errorf("error was expected while Insert stats: %s", err)
 	}
 
-	mock.ExpectExec("INSERT INTO items").
+	mock.ExpectQuery("INSERT INTO itemschema.items(.+) RETURNING id").
 		WithArgs(item.Name, item.Description, item.Price).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	ctx = context.Background()
-	if _, err = ur.Insert(ctx, item); err != nil {
-		t.Errorf("error was not expected while Insert stats: %s", err)
+	if id, err := ur.Insert(ctx, item); err != nil || id != 1 {
+		t.Errorf("error was not expected while Insert stats: %d, %s", id, err)
 	}
 
-	mock.ExpectExec("INSERT INTO items").
+	mock.ExpectQuery("INSERT INTO itemschema.items(.+) RETURNING id").
 		WithArgs(item.Name, item.Description, item.Price).
-		WillReturnResult(&lastInsertIDError{})
+		WillReturnError(fmt.Errorf("error"))
 	ctx = context.Background()
 	if _, err = ur.Insert(ctx, item); err == nil {
 		t.Errorf("error was expected while Insert stats: %s", err)
@@ -87,9 +81,9 @@ func TestSelectByID(t *testing.T) {
 		t.Errorf("error was expected while Select by ID stats: %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price"}).
-		AddRow(1, "Apple", "Red Apple", 120)
-	mock.ExpectQuery("^SELECT (.+) FROM items WHERE").
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "version"}).
+		AddRow(1, "Apple", "Red Apple", 120, 1)
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items WHERE").
 		WillReturnRows(rows)
 	ctx = context.Background()
 	_, err = ur.SelectByID(ctx, 1)
@@ -99,7 +93,7 @@ func TestSelectByID(t *testing.T) {
 
 	rows = sqlmock.NewRows([]string{"id", "BAD"}).
 		RowError(1, fmt.Errorf("error"))
-	mock.ExpectQuery("^SELECT (.+) FROM items WHERE").
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items WHERE").
 		WillReturnRows(rows)
 	ctx = context.Background()
 	_, err = ur.SelectByID(ctx, 1)
@@ -115,25 +109,27 @@ func TestSelectAll(t *testing.T) {
 	}
 	defer db.Close()
 	ur := NewItemRepository(db)
+	page := repo.Page{After: &api.Item{Id: 2}, Limit: 10}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	cancel()
-	if _, err = ur.SelectAll(ctx); err == nil {
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err == nil {
 		t.Errorf("error was expected while Select All stats: %s", err)
 	}
 
 	ctx = context.Background()
-	if _, err = ur.SelectAll(ctx); err == nil {
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err == nil {
 		t.Errorf("error was expected while Select All stats: %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "description", "price"}).
-		AddRow(1, "Apple", "Red Apple", 120).
-		AddRow(2, "Pen", "HB pencil", 100)
-	mock.ExpectQuery("^SELECT (.+) FROM items$").
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "version"}).
+		AddRow(1, "Apple", "Red Apple", 120, 1).
+		AddRow(2, "Pen", "HB pencil", 100, 1)
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items WHERE (.+) ORDER BY (.+) LIMIT").
+		WithArgs(page.After.Id, page.Limit).
 		WillReturnRows(rows)
 	ctx = context.Background()
-	if _, err = ur.SelectAll(ctx); err != nil {
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err != nil {
 		t.Errorf("error was not expected while Select All stats: %s", err)
 	}
 }
@@ -146,35 +142,62 @@ func TestUpdate(t *testing.T) {
 	defer db.Close()
 	ur := NewItemRepository(db)
 	item := &api.Item{Id: 1, Name: "Apple", Description: "Red Apple", Price: 500}
+	fields := []string{repo.FieldName, repo.FieldDescription, repo.FieldPrice}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	cancel()
-	if _, err = ur.Update(ctx, item); err == nil {
+	if _, err = ur.Update(ctx, item, fields); err == nil {
 		t.Errorf("error was expected while Update stats: %s", err)
 	}
 
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, item); err == nil {
+	if _, err = ur.Update(ctx, item, fields); err == nil {
 		t.Errorf("error was expected while Update stats: %s", err)
 	}
 
-	mock.ExpectExec("UPDATE items SET").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE itemschema.items SET").WillReturnResult(sqlmock.NewResult(1, 1))
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, item); err != nil {
+	if _, err = ur.Update(ctx, item, fields); err != nil {
 		t.Errorf("error was not expected while Update stats: %s", err)
 	}
 
-	mock.ExpectExec("UPDATE items SET").WillReturnResult(&rowsAffectedError{})
+	mock.ExpectExec("UPDATE itemschema.items SET").WillReturnResult(&rowsAffectedError{})
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, item); err == nil {
+	if _, err = ur.Update(ctx, item, fields); err == nil {
 		t.Errorf("error was expected while Update stats: %s", err)
 	}
 
-	mock.ExpectExec("UPDATE items SET").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectExec("UPDATE itemschema.items SET").WillReturnResult(sqlmock.NewResult(1, 0))
 	ctx = context.Background()
-	if _, err = ur.Update(ctx, item); err == nil {
+	if _, err = ur.Update(ctx, item, fields); err == nil {
 		t.Errorf("error was expected while Update stats: %s", err)
 	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE itemschema.items SET price=$1, version=version+1 WHERE id=$2")).
+		WithArgs(item.Price, item.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	if _, err = ur.Update(ctx, item, []string{repo.FieldPrice}); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	if _, err = ur.Update(ctx, item, []string{}); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+
+	stale := &api.Item{Id: 1, Version: 2}
+	mock.ExpectExec("UPDATE itemschema.items SET (.+) AND version=").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM itemschema.items").WithArgs(stale.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	if _, err = ur.Update(ctx, stale, []string{repo.FieldPrice}); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+
+	mock.ExpectExec("UPDATE itemschema.items SET (.+) AND version=").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM itemschema.items").WithArgs(stale.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	if _, err = ur.Update(ctx, stale, []string{repo.FieldPrice}); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
 }
 
 func TestDelete(t *testing.T) {
@@ -187,30 +210,98 @@ func TestDelete(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	cancel()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was expected while Delete stats: %s", err)
 	}
 
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was expected while Delete stats: %s", err)
 	}
 
-	mock.ExpectExec("DELETE FROM items WHERE").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM itemschema.items WHERE").WillReturnResult(sqlmock.NewResult(1, 1))
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err != nil {
+	if _, err = ur.Delete(ctx, 1, 0); err != nil {
 		t.Errorf("error was not expected while Delete stats: %s", err)
 	}
 
-	mock.ExpectExec("DELETE FROM items WHERE").WillReturnResult(&rowsAffectedError{})
+	mock.ExpectExec("DELETE FROM itemschema.items WHERE").WillReturnResult(&rowsAffectedError{})
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was expected while Delete stats: %s", err)
 	}
 
-	mock.ExpectExec("DELETE FROM items WHERE").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectExec("DELETE FROM itemschema.items WHERE").WillReturnResult(sqlmock.NewResult(1, 0))
 	ctx = context.Background()
-	if _, err = ur.Delete(ctx, 1); err == nil {
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
 		t.Errorf("error was expected while Delete stats: %s", err)
 	}
+
+	mock.ExpectExec("DELETE FROM itemschema.items WHERE (.+) AND version=").WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM itemschema.items").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	if _, err = ur.Delete(ctx, 1, 2); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+}
+
+func TestSelectEach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewItemRepository(db)
+	count := func(n *int) func(*api.Item) error {
+		return func(*api.Item) error { *n++; return nil }
+	}
+
+	var n int
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "version"}).
+		AddRow(1, "Pen", "black ink", 100, 1).
+		AddRow(2, "Notebook", "A5", 200, 1)
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items ORDER BY").
+		WillReturnRows(rows)
+	n = 0
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err != nil || n != 2 {
+		t.Errorf("want 2 rows visited but actual %d, %v", n, err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "BAD"}).
+		AddRow(1, "Bob")
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "description", "price", "version"}).
+		AddRow(1, "Pen", "black ink", 100, 1).
+		RowError(0, fmt.Errorf("error"))
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "description", "price", "version"}).
+		AddRow(1, "Pen", "black ink", 100, 1)
+	mock.ExpectQuery("^SELECT (.+) FROM itemschema.items ORDER BY").
+		WillReturnRows(rows)
+	stop := fmt.Errorf("stop")
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, func(*api.Item) error { return stop }); err != stop {
+		t.Errorf("want %v but actual %v", stop, err)
+	}
 }
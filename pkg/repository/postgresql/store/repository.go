@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+)
+
+type storeRepository struct {
+	db *sql.DB
+}
+
+func NewStoreRepository(db *sql.DB) repo.StoreRepository {
+	return &storeRepository{db: db}
+}
+
+func (s *storeRepository) connect(ctx context.Context) (*sql.Conn, error) {
+	c, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, dberr.Wrap("failed to connect to store database", err)
+	}
+	return c, nil
+}
+
+func (s *storeRepository) Insert(ctx context.Context, store *api.Store) (int64, error) {
+	c, err := s.connect(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer c.Close()
+
+	var id int64
+	err = c.QueryRowContext(ctx,
+		"INSERT INTO storeschema.stores(name, mail, address) VALUES($1, $2, $3) RETURNING id",
+		store.Name, store.Mail, store.Address).Scan(&id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to insert store", err)
+	}
+
+	return id, nil
+}
+
+func (s *storeRepository) SelectByID(ctx context.Context, id int64) (*api.Store, error) {
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	res, err := c.QueryContext(ctx,
+		"SELECT id, name, mail, address FROM storeschema.stores WHERE id = $1",
+		id)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select operation", err)
+	}
+	defer res.Close()
+
+	if !res.Next() {
+		if err := res.Err(); err != nil {
+			return nil, dberr.Wrap("failed to get data", err)
+		}
+		return nil, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	store := new(api.Store)
+	if err := res.Scan(&store.Id, &store.Name, &store.Mail, &store.Address); err != nil {
+		return nil, dberr.Wrap("failed to scan store", err)
+	}
+
+	return store, nil
+}
+
+func (s *storeRepository) SelectAll(ctx context.Context, page repo.Page) ([]*api.Store, error) {
+	c, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rows, err := c.QueryContext(ctx,
+		"SELECT id, name, mail, address FROM storeschema.stores WHERE id > $1 ORDER BY id LIMIT $2",
+		page.AfterID, page.Limit)
+	if err != nil {
+		return nil, dberr.Wrap("failed to select", err)
+	}
+	defer rows.Close()
+
+	list := []*api.Store{}
+	for rows.Next() {
+		store := new(api.Store)
+		if err := rows.Scan(&store.Id, &store.Name, &store.Mail, &store.Address); err != nil {
+			return nil, dberr.Wrap("failed to scan store", err)
+		}
+		list = append(list, store)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dberr.Wrap("failed to read stores", err)
+	}
+
+	return list, nil
+}
+
+func (s *storeRepository) Update(ctx context.Context, store *api.Store) (int64, error) {
+	c, err := s.connect(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer c.Close()
+
+	res, err := c.ExecContext(ctx,
+		"UPDATE storeschema.stores SET name=$1, mail=$2, address=$3 WHERE id=$4",
+		store.Name, store.Mail, store.Address, store.Id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to update store", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", store.Id)
+	}
+
+	return rows, nil
+}
+
+func (s *storeRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	c, err := s.connect(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer c.Close()
+
+	res, err := c.ExecContext(ctx, "DELETE FROM storeschema.stores WHERE id=$1", id)
+	if err != nil {
+		return -1, dberr.Wrap("failed to delete", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return -1, dberr.Wrap("failed to count affected rows", err)
+	}
+
+	if rows == 0 {
+		return -1, domain.Errorf(domain.NotFound, "ID='%d' is not found", id)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+)
+
+type rowsAffectedError struct{}
+
+func (rae *rowsAffectedError) LastInsertId() (int64, error) {
+	return 1, nil
+}
+func (rae *rowsAffectedError) RowsAffected() (int64, error) {
+	return 0, fmt.Errorf("error")
+}
+
+func TestInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sr := NewStoreRepository(db)
+	store := &api.Store{Name: "Shop", Mail: "shop@sample.com", Address: "Tokyo"}
+
+	ctx := context.Background()
+	if _, err = sr.Insert(ctx, store); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = sr.Insert(ctx, store); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	mock.ExpectQuery("INSERT INTO storeschema.stores(.+) RETURNING id").
+		WithArgs(store.Name, store.Mail, store.Address).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	ctx = context.Background()
+	if id, err := sr.Insert(ctx, store); err != nil || id != 1 {
+		t.Errorf("error was not expected while Insert stats: %d, %s", id, err)
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sr := NewStoreRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = sr.SelectByID(ctx, 1); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = sr.SelectByID(ctx, 1); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "mail", "address"}).
+		AddRow(1, "Shop", "shop@sample.com", "Tokyo")
+	mock.ExpectQuery("^SELECT (.+) FROM storeschema.stores WHERE").
+		WithArgs(1).
+		WillReturnRows(rows)
+	if _, err = sr.SelectByID(ctx, 1); err != nil {
+		t.Errorf("error was not expected while Select by ID stats: %s", err)
+	}
+
+	mock.ExpectQuery("^SELECT (.+) FROM storeschema.stores WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "mail", "address"}))
+	if _, err = sr.SelectByID(ctx, 2); err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sr := NewStoreRepository(db)
+
+	page := repo.Page{AfterID: 0, Limit: 10}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = sr.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = sr.SelectAll(ctx, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "mail", "address"}).
+		AddRow(1, "Shop", "shop@sample.com", "Tokyo").
+		AddRow(2, "Market", "market@sample.com", "London")
+	mock.ExpectQuery("^SELECT (.+) FROM storeschema.stores WHERE (.+) ORDER BY (.+) LIMIT").
+		WithArgs(page.AfterID, page.Limit).
+		WillReturnRows(rows)
+	if list, err := sr.SelectAll(ctx, page); err != nil || len(list) != 2 {
+		t.Errorf("error was not expected while Select All stats: %v, %s", list, err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sr := NewStoreRepository(db)
+	store := &api.Store{Id: 1, Name: "Shop", Mail: "shop@sample.com", Address: "Osaka"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = sr.Update(ctx, store); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = sr.Update(ctx, store); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE storeschema.stores SET").
+		WithArgs(store.Name, store.Mail, store.Address, store.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err = sr.Update(ctx, store); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE storeschema.stores SET").WillReturnResult(&rowsAffectedError{})
+	if _, err = sr.Update(ctx, store); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE storeschema.stores SET").WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err = sr.Update(ctx, store); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	sr := NewStoreRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = sr.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = sr.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM storeschema.stores WHERE").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err = sr.Delete(ctx, 1); err != nil {
+		t.Errorf("error was not expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM storeschema.stores WHERE").WillReturnResult(&rowsAffectedError{})
+	if _, err = sr.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM storeschema.stores WHERE").WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err = sr.Delete(ctx, 1); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+}
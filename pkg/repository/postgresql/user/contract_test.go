@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/mattn/go-sqlite3" // Register SQLite Driver
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"github.com/smockoro/grpc-microservice-sample/testdata/contract"
+)
+
+// sqliteUsers : SQLite stand-in for userschema.users of
+// docker/user-service/postgres. The schema is an attached database.
+var sqliteUsers = []string{
+	"ATTACH DATABASE ':memory:' AS userschema",
+	`CREATE TABLE userschema.users (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		age INTEGER NOT NULL,
+		mail TEXT NOT NULL,
+		address TEXT NOT NULL,
+		version INTEGER NOT NULL DEFAULT 1
+	)`,
+}
+
+func TestContractSQLite(t *testing.T) {
+	contract.TestUserRepository(t, func(t *testing.T) repo.UserRepository {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening sqlite", err)
+		}
+		db.SetMaxOpenConns(1) // every connection to :memory: is a new database
+		t.Cleanup(func() { db.Close() })
+
+		for _, stmt := range sqliteUsers {
+			if _, err := db.Exec(stmt); err != nil {
+				t.Fatalf("an error '%s' was not expected when creating users", err)
+			}
+		}
+		return NewUserRepository(db)
+	})
+}
+
+func TestContractSQLMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users WHERE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age", "mail", "address"}))
+	mock.ExpectExec("UPDATE userschema.users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM userschema.users").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	contract.MissingUserIsNotFound(t, NewUserRepository(db))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+// sortColumns : allow-list of order fields and the column each one sorts by.
+// order_by never reaches the SQL text unless it is a key of this map.
+var sortColumns = map[string]string{
+	"":               "id",
+	repo.OrderByID:   "id",
+	repo.OrderByName: "name",
+	repo.OrderByAge:  "age",
+	repo.OrderByMail: "mail",
+}
+
+// listQuery : build the SELECT of a user listing with every value bound as
+// a placeholder. limit 0 selects every matching row.
+func listQuery(f repo.Filter, o repo.Order, after *api.User, limit int) (string, []interface{}, error) {
+	column, ok := sortColumns[o.Field]
+	if !ok {
+		return "", nil, domain.Errorf(domain.Invalid, "users can't be ordered by %s", o.Field)
+	}
+
+	conds := []string{}
+	args := []interface{}{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.ID != nil {
+		conds = append(conds, "id = "+arg(*f.ID))
+	}
+	if f.NamePrefix != "" {
+		conds = append(conds, "name LIKE "+arg(escapeLike(f.NamePrefix)+"%")+" ESCAPE '!'")
+	}
+	if f.MinAge != nil {
+		conds = append(conds, "age >= "+arg(*f.MinAge))
+	}
+	if f.MaxAge != nil {
+		conds = append(conds, "age <= "+arg(*f.MaxAge))
+	}
+	if f.MailDomain != "" {
+		conds = append(conds, "mail LIKE "+arg("%@"+escapeLike(f.MailDomain))+" ESCAPE '!'")
+	}
+
+	cmp, dir := ">", ""
+	if o.Desc {
+		cmp, dir = "<", " DESC"
+	}
+	if after != nil {
+		if column == "id" {
+			conds = append(conds, "id "+cmp+" "+arg(after.Id))
+		} else {
+			value := fieldValue(o.Field, after)
+			conds = append(conds, fmt.Sprintf("(%s %s %s OR (%s = %s AND id > %s))",
+				column, cmp, arg(value), column, arg(value), arg(after.Id)))
+		}
+	}
+
+	query := "SELECT id, name, age, mail, address, version FROM userschema.users"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY " + column + dir
+	if column != "id" {
+		query += ", id"
+	}
+	if limit > 0 {
+		query += " LIMIT " + arg(limit)
+	}
+
+	return query, args, nil
+}
+
+// updateColumns : allow-list of fields Update can write and their columns
+var updateColumns = map[string]string{
+	repo.FieldName:    "name",
+	repo.FieldAge:     "age",
+	repo.FieldMail:    "mail",
+	repo.FieldAddress: "address",
+}
+
+// updateQuery : build the UPDATE of the given fields of user, matching
+// user.Version as well unless it is 0
+func updateQuery(user *api.User, fields []string) (string, []interface{}, error) {
+	sets := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	for _, field := range fields {
+		column, ok := updateColumns[field]
+		if !ok {
+			return "", nil, domain.Errorf(domain.Invalid, "user field %s can't be updated", field)
+		}
+		args = append(args, fieldValue(field, user))
+		sets = append(sets, fmt.Sprintf("%s=$%d", column, len(args)))
+	}
+	if len(sets) == 0 {
+		return "", nil, domain.Errorf(domain.Invalid, "no user field to update")
+	}
+	sets = append(sets, "version=version+1")
+
+	args = append(args, user.Id)
+	query := fmt.Sprintf("UPDATE userschema.users SET %s WHERE id=$%d", strings.Join(sets, ", "), len(args))
+	if user.Version != 0 {
+		args = append(args, user.Version)
+		query += fmt.Sprintf(" AND version=$%d", len(args))
+	}
+
+	return query, args, nil
+}
+
+// insertQuery : build one INSERT of every user returning the new ids
+func insertQuery(users []*api.User) (string, []interface{}) {
+	rows := make([]string, 0, len(users))
+	args := make([]interface{}, 0, 4*len(users))
+	for _, user := range users {
+		args = append(args, user.Name, user.Age, user.Mail, user.Address)
+		rows = append(rows, "("+placeholders(len(args)-4+1, 4)+")")
+	}
+
+	return "INSERT INTO userschema.users(name, age, mail, address) VALUES" + strings.Join(rows, ", ") + " RETURNING id", args
+}
+
+// selectByIDsQuery : build the SELECT of the users of ids
+func selectByIDsQuery(ids []int64) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return "SELECT id, name, age, mail, address, version FROM userschema.users WHERE id IN (" +
+		placeholders(1, len(ids)) + ") ORDER BY id", args
+}
+
+// deleteQuery : build one DELETE of the rows of keys. Keys with a version
+// match it as well, the others are matched by IN.
+func deleteQuery(keys []repo.Key) (string, []interface{}) {
+	ids := []interface{}{}
+	versioned := []repo.Key{}
+	for _, key := range keys {
+		if key.Version == 0 {
+			ids = append(ids, key.ID)
+		} else {
+			versioned = append(versioned, key)
+		}
+	}
+
+	conds := []string{}
+	args := ids
+	if len(ids) > 0 {
+		conds = append(conds, "id IN ("+placeholders(1, len(ids))+")")
+	}
+	for _, key := range versioned {
+		args = append(args, key.ID, key.Version)
+		conds = append(conds, fmt.Sprintf("(id=$%d AND version=$%d)", len(args)-1, len(args)))
+	}
+
+	return "DELETE FROM userschema.users WHERE " + strings.Join(conds, " OR "), args
+}
+
+// placeholders : n comma separated placeholders numbered from first
+func placeholders(first, n int) string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("$%d", first+i)
+	}
+	return strings.Join(list, ", ")
+}
+
+func fieldValue(field string, user *api.User) interface{} {
+	switch field {
+	case repo.FieldName:
+		return user.Name
+	case repo.FieldAge:
+		return user.Age
+	case repo.FieldMail:
+		return user.Mail
+	case repo.FieldAddress:
+		return user.Address
+	default:
+		return user.Id
+	}
+}
+
+// escapeLike : make s match literally inside a LIKE pattern with ESCAPE '!'
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
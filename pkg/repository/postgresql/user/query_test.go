@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+func TestListQuery(t *testing.T) {
+	age := int64(20)
+	id := int64(7)
+	cases := []struct {
+		name  string
+		f     repo.Filter
+		o     repo.Order
+		after *api.User
+		limit int
+		query string
+		args  []interface{}
+	}{
+		{name: "everything",
+			query: "SELECT id, name, age, mail, address, version FROM userschema.users ORDER BY id",
+			args:  []interface{}{}},
+		{name: "first page by id", limit: 10,
+			query: "SELECT id, name, age, mail, address, version FROM userschema.users ORDER BY id LIMIT $1",
+			args:  []interface{}{10}},
+		{name: "next page by id desc", o: repo.Order{Field: repo.OrderByID, Desc: true},
+			after: &api.User{Id: 5}, limit: 10,
+			query: "SELECT id, name, age, mail, address, version FROM userschema.users WHERE id < $1 " +
+				"ORDER BY id DESC LIMIT $2",
+			args: []interface{}{int64(5), 10}},
+		{name: "filtered next page by name",
+			f:     repo.Filter{NamePrefix: "B_b%", MinAge: &age, MaxAge: &age, MailDomain: "sample.com"},
+			o:     repo.Order{Field: repo.OrderByName},
+			after: &api.User{Id: 3, Name: "B_b%ob"}, limit: 2,
+			query: "SELECT id, name, age, mail, address, version FROM userschema.users WHERE name LIKE $1 ESCAPE '!' " +
+				"AND age >= $2 AND age <= $3 AND mail LIKE $4 ESCAPE '!' " +
+				"AND (name > $5 OR (name = $6 AND id > $7)) ORDER BY name, id LIMIT $8",
+			args: []interface{}{"B!_b!%%", age, age, "%@sample.com", "B_b%ob", "B_b%ob", int64(3), 2}},
+		{name: "one user", f: repo.Filter{ID: &id},
+			query: "SELECT id, name, age, mail, address, version FROM userschema.users WHERE id = $1 ORDER BY id",
+			args:  []interface{}{id}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			query, args, err := listQuery(c.f, c.o, c.after, c.limit)
+			if err != nil {
+				t.Fatalf("error was not expected: %s", err)
+			}
+			if query != c.query {
+				t.Errorf("want %q but actual %q", c.query, query)
+			}
+			if !reflect.DeepEqual(args, c.args) {
+				t.Errorf("want %#v but actual %#v", c.args, args)
+			}
+		})
+	}
+
+	if _, _, err := listQuery(repo.Filter{}, repo.Order{Field: "address"}, nil, 0); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+}
+
+func TestDeleteQuery(t *testing.T) {
+	query, args := deleteQuery([]repo.Key{{ID: 1}, {ID: 2, Version: 3}, {ID: 4}})
+	want := "DELETE FROM userschema.users WHERE id IN ($1, $2) OR (id=$3 AND version=$4)"
+	if query != want {
+		t.Errorf("want %s but actual %s", want, query)
+	}
+	if wantArgs := []interface{}{int64(1), int64(4), int64(2), int64(3)}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("want %v but actual %v", wantArgs, args)
+	}
+
+	query, _ = deleteQuery([]repo.Key{{ID: 2, Version: 3}})
+	if want := "DELETE FROM userschema.users WHERE (id=$1 AND version=$2)"; query != want {
+		t.Errorf("want %s but actual %s", want, query)
+	}
+}
+
+func TestInsertQuery(t *testing.T) {
+	query, args := insertQuery([]*api.User{{Name: "Bob", Age: 11}, {Name: "Alice", Age: 12}})
+	want := "INSERT INTO userschema.users(name, age, mail, address) VALUES($1, $2, $3, $4), ($5, $6, $7, $8) RETURNING id"
+	if query != want {
+		t.Errorf("want %s but actual %s", want, query)
+	}
+	if len(args) != 8 || args[0] != "Bob" || args[5] != int64(12) {
+		t.Errorf("want args of Bob and Alice but actual %v", args)
+	}
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+)
+
+type rowsAffectedError struct{}
+
+func (rae *rowsAffectedError) LastInsertId() (int64, error) {
+	return 1, nil
+}
+func (rae *rowsAffectedError) RowsAffected() (int64, error) {
+	return 0, fmt.Errorf("error")
+}
+
+func TestInsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ur := NewUserRepository(db)
+	user := &api.User{Name: "Bob", Age: 11, Mail: "sample@sample.com", Address: "Tokyo"}
+
+	ctx := context.Background()
+	if _, err = ur.Insert(ctx, user); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = ur.Insert(ctx, user); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+
+	mock.ExpectQuery("INSERT INTO userschema.users(.+) RETURNING id").
+		WithArgs(user.Name, user.Age, user.Mail, user.Address).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	ctx = context.Background()
+	if id, err := ur.Insert(ctx, user); err != nil || id != 1 {
+		t.Errorf("error was not expected while Insert stats: %d, %s", id, err)
+	}
+
+	mock.ExpectQuery("INSERT INTO userschema.users(.+) RETURNING id").
+		WithArgs(user.Name, user.Age, user.Mail, user.Address).
+		WillReturnError(fmt.Errorf("error"))
+	ctx = context.Background()
+	if _, err = ur.Insert(ctx, user); err == nil {
+		t.Errorf("error was expected while Insert stats: %s", err)
+	}
+}
+
+func TestSelectByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewUserRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	_, err = ur.SelectByID(ctx, 1)
+	if err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	ctx = context.Background()
+	_, err = ur.SelectByID(ctx, 1)
+	if err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "age", "mail", "address", "version"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo", 1)
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users WHERE").
+		WillReturnRows(rows)
+	ctx = context.Background()
+	_, err = ur.SelectByID(ctx, 1)
+	if err != nil {
+		t.Errorf("error was not expected while Select by ID stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "BAD"}).
+		RowError(1, fmt.Errorf("error"))
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users WHERE").
+		WillReturnRows(rows)
+	ctx = context.Background()
+	_, err = ur.SelectByID(ctx, 1)
+	if err == nil {
+		t.Errorf("error was expected while Select by ID stats: %s", err)
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewUserRepository(db)
+	page := repo.Page{After: &api.User{Id: 2}, Limit: 10}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err == nil {
+		t.Errorf("error was expected while Select All stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "age", "mail", "address", "version"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo", 1).
+		AddRow(2, "Alice", 13, "example@sample.com", "London", 1)
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users WHERE (.+) ORDER BY (.+) LIMIT").
+		WithArgs(page.After.Id, page.Limit).
+		WillReturnRows(rows)
+	ctx = context.Background()
+	if _, err = ur.SelectAll(ctx, repo.Filter{}, repo.Order{}, page); err != nil {
+		t.Errorf("error was not expected while Select All stats: %s", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewUserRepository(db)
+	user := &api.User{Id: 1, Name: "Bob Olimar", Age: 88, Mail: "aa@sample.com", Address: "Tokyo"}
+	fields := []string{repo.FieldName, repo.FieldAge, repo.FieldMail, repo.FieldAddress}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = ur.Update(ctx, user, fields); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = ur.Update(ctx, user, fields); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE userschema.users SET").WillReturnResult(sqlmock.NewResult(1, 1))
+	ctx = context.Background()
+	if _, err = ur.Update(ctx, user, fields); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE userschema.users SET").WillReturnResult(&rowsAffectedError{})
+	ctx = context.Background()
+	if _, err = ur.Update(ctx, user, fields); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec("UPDATE userschema.users SET").WillReturnResult(sqlmock.NewResult(1, 0))
+	ctx = context.Background()
+	if _, err = ur.Update(ctx, user, fields); err == nil {
+		t.Errorf("error was expected while Update stats: %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE userschema.users SET age=$1, address=$2, version=version+1 WHERE id=$3")).
+		WithArgs(user.Age, user.Address, user.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	if _, err = ur.Update(ctx, user, []string{repo.FieldAge, repo.FieldAddress}); err != nil {
+		t.Errorf("error was not expected while Update stats: %s", err)
+	}
+
+	if _, err = ur.Update(ctx, user, []string{}); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("want %v but actual %v", domain.Invalid, err)
+	}
+
+	stale := &api.User{Id: 1, Version: 2}
+	mock.ExpectExec("UPDATE userschema.users SET (.+) AND version=").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM userschema.users").WithArgs(stale.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	if _, err = ur.Update(ctx, stale, []string{repo.FieldName}); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+
+	mock.ExpectExec("UPDATE userschema.users SET (.+) AND version=").WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM userschema.users").WithArgs(stale.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	if _, err = ur.Update(ctx, stale, []string{repo.FieldName}); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewUserRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	cancel()
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM userschema.users WHERE").WillReturnResult(sqlmock.NewResult(1, 1))
+	ctx = context.Background()
+	if _, err = ur.Delete(ctx, 1, 0); err != nil {
+		t.Errorf("error was not expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM userschema.users WHERE").WillReturnResult(&rowsAffectedError{})
+	ctx = context.Background()
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM userschema.users WHERE").WillReturnResult(sqlmock.NewResult(1, 0))
+	ctx = context.Background()
+	if _, err = ur.Delete(ctx, 1, 0); err == nil {
+		t.Errorf("error was expected while Delete stats: %s", err)
+	}
+
+	mock.ExpectExec("DELETE FROM userschema.users WHERE (.+) AND version=").WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(1, 0))
+	mock.ExpectQuery("SELECT version FROM userschema.users").
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	if _, err = ur.Delete(ctx, 1, 2); domain.KindOf(err) != domain.Conflict {
+		t.Errorf("want %v but actual %v", domain.Conflict, err)
+	}
+}
+
+func TestSelectEach(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	ur := NewUserRepository(db)
+	count := func(n *int) func(*api.User) error {
+		return func(*api.User) error { *n++; return nil }
+	}
+
+	var n int
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	ctx = context.Background()
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "age", "mail", "address", "version"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo", 1).
+		AddRow(2, "Alice", 13, "example@sample.com", "London", 1)
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users ORDER BY").
+		WillReturnRows(rows)
+	n = 0
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err != nil || n != 2 {
+		t.Errorf("want 2 rows visited but actual %d, %v", n, err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "BAD"}).
+		AddRow(1, "Bob")
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "age", "mail", "address", "version"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo", 1).
+		RowError(0, fmt.Errorf("error"))
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users ORDER BY").
+		WillReturnRows(rows)
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, count(&n)); err == nil {
+		t.Errorf("error was expected while Select Each stats: %s", err)
+	}
+
+	rows = sqlmock.NewRows([]string{"id", "name", "age", "mail", "address", "version"}).
+		AddRow(1, "Bob", 11, "sample@sample.com", "Tokyo", 1)
+	mock.ExpectQuery("^SELECT (.+) FROM userschema.users ORDER BY").
+		WillReturnRows(rows)
+	stop := fmt.Errorf("stop")
+	if err = ur.SelectEach(ctx, repo.Filter{}, repo.Order{}, func(*api.User) error { return stop }); err != stop {
+		t.Errorf("want %v but actual %v", stop, err)
+	}
+}
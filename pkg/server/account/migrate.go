@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/account"
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var mysqlMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_accounts",
+		Up: []string{
+			"CREATE TABLE IF NOT EXISTS `accounts` (" +
+				"`account_id` bigint(20) NOT NULL AUTO_INCREMENT, " +
+				"`date` varchar(64) DEFAULT NULL, " +
+				"`store_id` bigint(20) DEFAULT NULL, " +
+				"PRIMARY KEY (`account_id`))",
+			"CREATE TABLE IF NOT EXISTS `account_details` (" +
+				"`id` bigint(20) NOT NULL AUTO_INCREMENT, " +
+				"`account_id` bigint(20) NOT NULL, " +
+				"`item_id` bigint(20) NOT NULL, " +
+				"PRIMARY KEY (`id`), " +
+				"KEY `ACCOUNT_ID_INDEX` (`account_id`), " +
+				"CONSTRAINT `account_details_account_id_fk` FOREIGN KEY (`account_id`) " +
+				"REFERENCES `accounts` (`account_id`) ON DELETE CASCADE)",
+		},
+		Down: []string{"DROP TABLE `account_details`", "DROP TABLE `accounts`"},
+	},
+}
+
+// RunMigration : run `migrate up|down|status` against the mysql server
+func RunMigration(args []string) error {
+	cfg := config.NewConfig()
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migration.NewMigrator(db.DB, migration.DialectMySQL, mysqlMigrations)
+	if err != nil {
+		return err
+	}
+	return migration.Run(context.Background(), m, args, os.Stdout)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"fmt"
+
+	_ "github.com/go-sql-driver/mysql" // Register MySQL Driver
+	"github.com/jmoiron/sqlx"
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/book"
+)
+
+// ConnectDB : connect to mysql server
+func ConnectDB(cfg *config.Config) (*sqlx.DB, error) {
+	if cfg.DBUser == "" {
+		return nil, fmt.Errorf("DBUser is none")
+	}
+	if cfg.DBPassword == "" {
+		return nil, fmt.Errorf("DBPassword is none")
+	}
+	if cfg.DBHost == "" {
+		return nil, fmt.Errorf("DBHost is none")
+	}
+	if cfg.DBSchema == "" {
+		return nil, fmt.Errorf("DBSchema is none")
+	}
+
+	// clientFoundRows makes RowsAffected count matched rows, so an Update
+	// which changes nothing is not mistaken for a missing book.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?clientFoundRows=true",
+		cfg.DBUser,
+		cfg.DBPassword,
+		cfg.DBHost,
+		cfg.DBSchema)
+	return sqlx.Open("mysql", dsn)
+}
@@ -0,0 +1,10 @@
+package server
+
+import (
+	"context"
+	"net/http"
+)
+
+func ExportNewGateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	return newGateway(ctx, endpoint)
+}
@@ -0,0 +1,138 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	server "github.com/smockoro/grpc-microservice-sample/pkg/server/book"
+	"github.com/smockoro/grpc-microservice-sample/testdata/token"
+	"google.golang.org/grpc"
+)
+
+type fakeBookServer struct {
+	updated *api.Book
+	deleted int64
+}
+
+func (f *fakeBookServer) GetAll(ctx context.Context, req *api.GetAllRequest) (*api.GetAllResponse, error) {
+	return &api.GetAllResponse{Books: []*api.Book{&api.Book{Id: 1, Title: "Go"}}}, nil
+}
+
+func (f *fakeBookServer) Create(ctx context.Context, req *api.CreateRequest) (*api.CreateResponse, error) {
+	return &api.CreateResponse{Id: 2}, nil
+}
+
+func (f *fakeBookServer) Get(ctx context.Context, req *api.GetRequest) (*api.GetResponse, error) {
+	return &api.GetResponse{Book: &api.Book{Id: req.Id, Title: "Go"}}, nil
+}
+
+func (f *fakeBookServer) Update(ctx context.Context, req *api.UpdateRequest) (*api.UpdateResponse, error) {
+	f.updated = req.Book
+	return &api.UpdateResponse{Updated: 1}, nil
+}
+
+func (f *fakeBookServer) Delete(ctx context.Context, req *api.DeleteRequest) (*api.DeleteResponse, error) {
+	f.deleted = req.Id
+	return &api.DeleteResponse{Deleted: 1}, nil
+}
+
+func TestGateway(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	authFunc, err := auth.NewAuthFunc(auth.Config{Secret: token.Secret})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	bearer := "Bearer " + token.Sign(t, jwt.MapClaims{"sub": "1"})
+	fake := &fakeBookServer{}
+	s := grpc.NewServer(grpc.UnaryInterceptor(grpc_auth.UnaryServerInterceptor(authFunc)))
+	api.RegisterBookServiceServer(s, fake)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gw, err := server.ExportNewGateway(ctx, lis.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to register gateway: %v", err)
+	}
+	hs := httptest.NewServer(gw)
+	defer hs.Close()
+
+	do := func(t *testing.T, method, path, body string, auth bool) (*http.Response, map[string]interface{}) {
+		t.Helper()
+		req, err := http.NewRequest(method, hs.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth {
+			req.Header.Set("Authorization", bearer)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var decoded map[string]interface{}
+		json.NewDecoder(res.Body).Decode(&decoded)
+		return res, decoded
+	}
+
+	t.Run("GetAll_NotAuthorizationHeader", func(t *testing.T) {
+		res, _ := do(t, http.MethodGet, "/book/all", "", false)
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Errorf("want %d but actual %d", http.StatusUnauthorized, res.StatusCode)
+		}
+	})
+	t.Run("GetAll_AuthorizationHeader", func(t *testing.T) {
+		res, body := do(t, http.MethodGet, "/book/all", "", true)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("want %d but actual %d", http.StatusOK, res.StatusCode)
+		}
+		if books, ok := body["books"].([]interface{}); !ok || len(books) != 1 {
+			t.Errorf("want one book but actual %v", body)
+		}
+	})
+	t.Run("Create", func(t *testing.T) {
+		res, body := do(t, http.MethodPost, "/book", `{"book": {"title": "Go"}}`, true)
+		if res.StatusCode != http.StatusOK || body["id"] != "2" {
+			t.Errorf("want id 2 but actual %d %v", res.StatusCode, body)
+		}
+	})
+	t.Run("Get", func(t *testing.T) {
+		res, body := do(t, http.MethodGet, "/book/5", "", true)
+		book, _ := body["book"].(map[string]interface{})
+		if res.StatusCode != http.StatusOK || book["id"] != "5" {
+			t.Errorf("want book 5 but actual %d %v", res.StatusCode, body)
+		}
+	})
+	t.Run("Update", func(t *testing.T) {
+		res, _ := do(t, http.MethodPut, "/book/3", `{"book": {"title": "Go 2nd"}}`, true)
+		if res.StatusCode != http.StatusOK || fake.updated.GetId() != 3 || fake.updated.GetTitle() != "Go 2nd" {
+			t.Errorf("want book 3 updated but actual %d %v", res.StatusCode, fake.updated)
+		}
+	})
+	t.Run("Patch", func(t *testing.T) {
+		res, _ := do(t, http.MethodPatch, "/book/4", `{"book": {"title": "Go 3rd"}}`, true)
+		if res.StatusCode != http.StatusOK || fake.updated.GetId() != 4 {
+			t.Errorf("want book 4 updated but actual %d %v", res.StatusCode, fake.updated)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		res, _ := do(t, http.MethodDelete, "/book/3", "", true)
+		if res.StatusCode != http.StatusOK || fake.deleted != 3 {
+			t.Errorf("want book 3 deleted but actual %d %d", res.StatusCode, fake.deleted)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/book"
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var mysqlMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_books",
+		Up: []string{
+			"CREATE TABLE IF NOT EXISTS `books` (" +
+				"`id` bigint(20) NOT NULL AUTO_INCREMENT, " +
+				"`title` varchar(200) DEFAULT NULL, " +
+				"`author` varchar(200) DEFAULT NULL, " +
+				"`description` varchar(1024) DEFAULT NULL, " +
+				"`pages` bigint(20) DEFAULT NULL, " +
+				"`price` bigint(20) DEFAULT NULL, " +
+				"PRIMARY KEY (`id`))",
+		},
+		Down: []string{"DROP TABLE `books`"},
+	},
+}
+
+// RunMigration : run `migrate up|down|status` against the mysql server
+func RunMigration(args []string) error {
+	cfg := config.NewConfig()
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migration.NewMigrator(db.DB, migration.DialectMySQL, mysqlMigrations)
+	if err != nil {
+		return err
+	}
+	return migration.Run(context.Background(), m, args, os.Stdout)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/book"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/book"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/errlog"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/grpcerr"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/healthcheck"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/book"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// RunServer : Component Injected and Startup gRPC Server and HTTP/JSON Gateway
+func RunServer() error {
+	cfg := config.NewConfig()
+
+	authFunc, err := auth.NewAuthFunc(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure authentication: %v", err)
+	}
+	policy, err := auth.LoadPolicy(cfg.AuthzPolicyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load authorization policy: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", ":"+cfg.Port)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	stackTracer := lib.NewStackTracer()
+	repo := repo.NewBookRepository(db)
+	pageTokenizer, err := lib.NewPageTokenizer([]byte(cfg.PageTokenSecret))
+	if err != nil {
+		return err
+	}
+	server := book.NewBookServiceServer(repo, stackTracer, pageTokenizer)
+
+	opts := []grpc_zap.Option{}
+	zapLogger, _ := zap.NewProduction()
+	grpc_zap.ReplaceGrpcLogger(zapLogger)
+
+	s := grpc.NewServer(
+		grpc_middleware.WithUnaryServerChain(
+			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(
+				grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			grpc_zap.UnaryServerInterceptor(zapLogger, opts...),
+			errlog.UnaryServerInterceptor(),
+			grpcerr.UnaryServerInterceptor(),
+			grpc_auth.UnaryServerInterceptor(authFunc),
+			policy.UnaryServerInterceptor(),
+		),
+	)
+
+	api.RegisterBookServiceServer(s, server)
+	checker := healthcheck.NewChecker(db, healthcheck.DefaultInterval, "api.BookService")
+	checker.Register(s)
+	reflection.Register(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Run(ctx)
+
+	gw, err := newGateway(ctx, "localhost:"+cfg.Port)
+	if err != nil {
+		return fmt.Errorf("failed to register gateway: %v", err)
+	}
+	hs := &http.Server{Addr: ":" + cfg.HTTPPort, Handler: gw}
+
+	errc := make(chan error, 2)
+	go func() {
+		log.Println("starting gRPC server...")
+		errc <- fmt.Errorf("failed to serve: %v", s.Serve(lis))
+	}()
+	go func() {
+		log.Println("starting HTTP/JSON gateway...")
+		errc <- fmt.Errorf("failed to serve gateway: %v", hs.ListenAndServe())
+	}()
+
+	err = <-errc
+	hs.Close()
+	s.Stop()
+	return err
+}
+
+// newGateway : the gateway calls the gRPC listener as a client, so REST
+// requests go through the same interceptor chain as gRPC requests and the
+// Authorization header is forwarded as metadata.
+func newGateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux()
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if err := api.RegisterBookServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
@@ -0,0 +1,213 @@
+// Package errlog records failed RPCs for on-call engineers. The frames
+// lib.StackTracer and xerrors put in an error chain go to the call log as
+// a structured field, and a correlation ID ties the log line to the
+// status the client receives. The frames never leave the server.
+package errlog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	ctxzap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/xerrors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CorrelationIDKey : metadata key of the correlation ID, read from the
+// request when the client sets it and returned in the trailer
+const CorrelationIDKey = "x-correlation-id"
+
+// Frame : one annotated layer of an error chain
+type Frame struct {
+	Message  string
+	Function string
+	Location string
+}
+
+// MarshalLogObject : zapcore.ObjectMarshaler
+func (f Frame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", f.Message)
+	if f.Function != "" {
+		enc.AddString("function", f.Function)
+	}
+	if f.Location != "" {
+		enc.AddString("location", f.Location)
+	}
+	return nil
+}
+
+// Frames : zapcore.ArrayMarshaler for a stack
+type Frames []Frame
+
+// MarshalLogArray : zapcore.ArrayMarshaler
+func (fs Frames) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, f := range fs {
+		if err := enc.AppendObject(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stack : the frames of the layers of err which format themselves through
+// xerrors, from the outermost one. Layers without a frame are skipped.
+func Stack(err error) Frames {
+	var frames Frames
+	for err != nil {
+		f, ok := err.(xerrors.Formatter)
+		if !ok {
+			err = xerrors.Unwrap(err)
+			continue
+		}
+		p := &printer{}
+		next := f.FormatError(p)
+		if frame, ok := p.frame(); ok {
+			frames = append(frames, frame)
+		}
+		err = next
+	}
+	return frames
+}
+
+// printer : xerrors.Printer collecting what a layer prints in detail mode.
+// Layers print their message with Print and xerrors.Frame prints
+// "function\n    file:line\n" with Printf.
+type printer struct {
+	msg    strings.Builder
+	detail strings.Builder
+}
+
+func (p *printer) Print(args ...interface{}) {
+	fmt.Fprint(&p.msg, args...)
+}
+
+func (p *printer) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&p.detail, format, args...)
+}
+
+func (p *printer) Detail() bool {
+	return true
+}
+
+func (p *printer) frame() (Frame, bool) {
+	lines := strings.Split(strings.TrimSpace(p.detail.String()), "\n")
+	if len(lines) != 2 {
+		return Frame{}, false
+	}
+	return Frame{
+		Message:  p.msg.String(),
+		Function: strings.TrimSpace(lines[0]),
+		Location: strings.TrimSpace(lines[1]),
+	}, true
+}
+
+// UnaryServerInterceptor : log the stack of failed calls with a correlation
+// ID, which the client receives in the trailer and as RequestInfo detail.
+// It has to run inside grpc_zap to reach the call log and outside grpcerr
+// to add the ID to the final status.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		id := record(ctx, err)
+		if terr := grpc.SetTrailer(ctx, metadata.Pairs(CorrelationIDKey, id)); terr != nil {
+			ctxzap.Extract(ctx).Warn("failed to set correlation id trailer", zap.Error(terr))
+		}
+		return resp, withRequestInfo(err, id)
+	}
+}
+
+// StreamServerInterceptor : UnaryServerInterceptor for streams
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+		id := record(ss.Context(), err)
+		ss.SetTrailer(metadata.Pairs(CorrelationIDKey, id))
+		return withRequestInfo(err, id)
+	}
+}
+
+// record : add the correlation ID and the stack of err to the call log
+func record(ctx context.Context, err error) string {
+	id := correlationID(ctx)
+	fields := []zapcore.Field{zap.String("correlation_id", id)}
+	if stack := Stack(err); len(stack) > 0 {
+		fields = append(fields, zap.Array("error.stack", stack))
+	}
+	ctxzap.AddFields(ctx, fields...)
+	return id
+}
+
+// maxCorrelationIDLen : longest correlation ID taken from a client
+const maxCorrelationIDLen = 64
+
+// correlationID : the ID sent by the client, a new random one otherwise.
+// It ends up in the log and the response, so an ID which is too long or
+// has characters other than [A-Za-z0-9-_] is replaced too.
+func correlationID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(CorrelationIDKey); len(ids) > 0 && validCorrelationID(ids[0]) {
+			return ids[0]
+		}
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// withRequestInfo : err whose status also carries id as RequestInfo
+func withRequestInfo(err error, id string) error {
+	s, derr := status.Convert(err).WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if derr != nil {
+		return err
+	}
+	return &correlatedError{status: s, err: err}
+}
+
+type correlatedError struct {
+	status *status.Status
+	err    error
+}
+
+func (e *correlatedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *correlatedError) Unwrap() error {
+	return e.err
+}
+
+func (e *correlatedError) GRPCStatus() *status.Status {
+	return e.status
+}
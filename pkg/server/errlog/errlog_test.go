@@ -0,0 +1,118 @@
+package errlog_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	ctxzap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/errlog"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/grpcerr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestStack(t *testing.T) {
+	st := lib.NewStackTracer()
+	err := st.Wrap("can't get user by id", domain.Errorf(domain.NotFound, "ID='1' is not found"))
+
+	stack := errlog.Stack(err)
+	if len(stack) != 1 {
+		t.Fatalf("want 1 frame but actual %v", stack)
+	}
+	if stack[0].Message != "can't get user by id" || !strings.Contains(stack[0].Location, "errlog_test.go") ||
+		!strings.Contains(stack[0].Function, "TestStack") {
+		t.Errorf("want the frame of TestStack but actual %+v", stack[0])
+	}
+	if stack := errlog.Stack(domain.Errorf(domain.NotFound, "ID='1' is not found")); len(stack) != 0 {
+		t.Errorf("want no frame but actual %v", stack)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	st := lib.NewStackTracer()
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, grpcerr.Error(st.Wrap("can't get user by id", scanFailure()))
+	}
+
+	cases := []struct {
+		name     string
+		md       metadata.MD
+		id       string
+		replaced string
+	}{
+		{name: "new id", md: metadata.MD{}},
+		{name: "id from client", md: metadata.Pairs(errlog.CorrelationIDKey, "abc-123_XYZ"), id: "abc-123_XYZ"},
+		{name: "id from client with unsafe characters",
+			md: metadata.Pairs(errlog.CorrelationIDKey, "abc\nlevel=error"), replaced: "abc\nlevel=error"},
+		{name: "too long id from client",
+			md: metadata.Pairs(errlog.CorrelationIDKey, strings.Repeat("a", 65)), replaced: strings.Repeat("a", 65)},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			ctx := ctxzap.ToContext(metadata.NewIncomingContext(context.Background(), c.md), zap.New(core))
+
+			_, err := errlog.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, failing)
+			s := status.Convert(err)
+			if s.Code() != codes.Internal || s.Message() != "internal error" {
+				t.Errorf("want Internal %q but actual %v %q", "internal error", s.Code(), s.Message())
+			}
+			var id string
+			for _, d := range s.Details() {
+				if info, ok := d.(*errdetails.RequestInfo); ok {
+					id = info.RequestId
+				}
+			}
+			if id == "" || (c.id != "" && id != c.id) {
+				t.Errorf("want correlation id %q in details but actual %q", c.id, id)
+			}
+			if id == c.replaced {
+				t.Errorf("want a new correlation id instead of %q", c.replaced)
+			}
+
+			ctxzap.Extract(ctx).Info("finished unary call")
+			entry := logs.FilterMessage("finished unary call").All()[0]
+			fields := entry.ContextMap()
+			if fields["correlation_id"] != id {
+				t.Errorf("want correlation_id %q but actual %v", id, fields["correlation_id"])
+			}
+			stack, ok := fields["error.stack"].([]interface{})
+			if !ok || len(stack) != 1 {
+				t.Fatalf("want 1 frame but actual %v", fields["error.stack"])
+			}
+			frame := stack[0].(map[string]interface{})
+			if !strings.Contains(frame["location"].(string), "errlog_test.go") {
+				t.Errorf("want the frame of the test in %v", frame)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptorSuccess(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := ctxzap.ToContext(context.Background(), zap.New(core))
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	if _, err := errlog.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, ok); err != nil {
+		t.Fatalf("want nil but actual %v", err)
+	}
+	ctxzap.Extract(ctx).Info("finished unary call")
+	if _, ok := logs.All()[0].ContextMap()["correlation_id"]; ok {
+		t.Errorf("want no correlation_id for a successful call")
+	}
+}
+
+func scanFailure() error {
+	return domain.Wrap(domain.Internal, "failed to scan user", errors.New("sql: Scan error on column index 2"))
+}
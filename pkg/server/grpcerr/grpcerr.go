@@ -0,0 +1,97 @@
+// Package grpcerr translates the errors of services and repositories into
+// gRPC statuses. It is the only place a domain error meets a gRPC code.
+package grpcerr
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// internalMessage : what clients see of an error without a safe message
+const internalMessage = "internal error"
+
+var codeOfKind = map[domain.Kind]codes.Code{
+	domain.Internal:      codes.Internal,
+	domain.NotFound:      codes.NotFound,
+	domain.AlreadyExists: codes.AlreadyExists,
+	domain.Conflict:      codes.Aborted,
+	domain.Unavailable:   codes.Unavailable,
+	domain.Invalid:       codes.InvalidArgument,
+}
+
+// Status : the status clients receive for err.
+// Context errors get Canceled or DeadlineExceeded even when a repository
+// wrapped them into a domain error. Domain errors get the code of their
+// kind and their message without the cause and statuses of the service
+// layer pass through. Anything else is Internal.
+func Status(err error) *status.Status {
+	switch {
+	case xerrors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, context.Canceled.Error())
+	case xerrors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	var de *domain.Error
+	if xerrors.As(err, &de) {
+		if de.Kind == domain.Internal {
+			return status.New(codes.Internal, internalMessage)
+		}
+		return status.New(codeOfKind[de.Kind], de.Msg)
+	}
+
+	for e := err; e != nil; e = xerrors.Unwrap(e) {
+		if se, ok := e.(interface{ GRPCStatus() *status.Status }); ok && se.GRPCStatus().Code() != codes.Unknown {
+			return se.GRPCStatus()
+		}
+	}
+	return status.New(codes.Internal, internalMessage)
+}
+
+// Error : err carrying Status(err) for clients. Its Error() still holds
+// the whole chain for server logs. nil stays nil.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &statusError{status: Status(err), err: err}
+}
+
+type statusError struct {
+	status *status.Status
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// UnaryServerInterceptor : translate the error of handler with Error
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, Error(err)
+	}
+}
+
+// StreamServerInterceptor : translate the error of handler with Error
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return Error(handler(srv, ss))
+	}
+}
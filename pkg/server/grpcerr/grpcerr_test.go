@@ -0,0 +1,62 @@
+package grpcerr_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	"github.com/smockoro/grpc-microservice-sample/pkg/repository/dberr"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/grpcerr"
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	st := lib.NewStackTracer()
+	cause := xerrors.New("dial tcp 10.0.0.1:3306: connect: connection refused")
+
+	cases := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{name: "not found", err: domain.Errorf(domain.NotFound, "ID='1' is not found"),
+			code: codes.NotFound, message: "ID='1' is not found"},
+		{name: "conflict under stack tracer", err: st.Wrap("can't update user", domain.Errorf(domain.Conflict, "ID='1' has version 2")),
+			code: codes.Aborted, message: "ID='1' has version 2"},
+		{name: "unavailable hides cause", err: domain.Wrap(domain.Unavailable, "failed to connect to user database", cause),
+			code: codes.Unavailable, message: "failed to connect to user database"},
+		{name: "internal hides message", err: domain.Wrap(domain.Internal, "failed to scan user", cause),
+			code: codes.Internal, message: "internal error"},
+		{name: "canceled", err: st.Wrap("can't get user", context.Canceled),
+			code: codes.Canceled, message: context.Canceled.Error()},
+		{name: "deadline under repository error", err: dberr.Wrap("failed to select", context.DeadlineExceeded),
+			code: codes.DeadlineExceeded, message: context.DeadlineExceeded.Error()},
+		{name: "service status", err: status.Error(codes.InvalidArgument, "users[0] is not set"),
+			code: codes.InvalidArgument, message: "users[0] is not set"},
+		{name: "plain error", err: st.Wrap("can't create user", cause),
+			code: codes.Internal, message: "internal error"},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			s := status.Convert(grpcerr.Error(c.err))
+			if s.Code() != c.code || s.Message() != c.message {
+				t.Errorf("want %v %q but actual %v %q", c.code, c.message, s.Code(), s.Message())
+			}
+		})
+	}
+
+	err := grpcerr.Error(domain.Wrap(domain.Unavailable, "failed to connect to user database", cause))
+	if !strings.Contains(err.Error(), cause.Error()) {
+		t.Errorf("want the cause in %q", err.Error())
+	}
+	if grpcerr.Error(nil) != nil {
+		t.Errorf("want nil for nil")
+	}
+}
@@ -0,0 +1,90 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/healthcheck"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeDB struct {
+	err error
+}
+
+func (db *fakeDB) PingContext(ctx context.Context) error {
+	return db.err
+}
+
+func TestChecker(t *testing.T) {
+	db := &fakeDB{}
+	checker := healthcheck.NewChecker(db, time.Hour, "api.UserService")
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.UnaryInterceptor(grpc_auth.UnaryServerInterceptor(
+		func(ctx context.Context) (context.Context, error) {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		})))
+	checker.Register(s)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) { return lis.Dial() }))
+	if err != nil {
+		t.Fatalf("Did not connect to server: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	expect := func(t *testing.T, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("want nil but actual %v", err)
+		}
+		if resp.Status != want {
+			t.Errorf("%q: want %v but actual %v", service, want, resp.Status)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		setup func(ctx context.Context)
+		want  healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "before first ping", setup: func(ctx context.Context) {},
+			want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "ping succeeds", setup: func(ctx context.Context) { checker.Check(ctx) },
+			want: healthpb.HealthCheckResponse_SERVING},
+		{name: "ping fails", setup: func(ctx context.Context) {
+			db.err = errors.New("connection refused")
+			checker.Check(ctx)
+		}, want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "ping recovers", setup: func(ctx context.Context) {
+			db.err = nil
+			checker.Check(ctx)
+		}, want: healthpb.HealthCheckResponse_SERVING},
+		{name: "draining", setup: func(ctx context.Context) {
+			checker.Drain()
+			checker.Check(ctx)
+		}, want: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			c.setup(context.Background())
+			expect(t, "", c.want)
+			expect(t, "api.UserService", c.want)
+		})
+	}
+}
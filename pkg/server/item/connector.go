@@ -0,0 +1,35 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "github.com/lib/pq" // Register PostgreSQL Driver
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/item"
+)
+
+// ConnectDB : connect to postgresql server
+func ConnectDB(cfg *config.Config) (*sql.DB, error) {
+	if cfg.DBUser == "" {
+		return nil, fmt.Errorf("DBUser is none")
+	}
+	if cfg.DBPassword == "" {
+		return nil, fmt.Errorf("DBPassword is none")
+	}
+	if cfg.DBHost == "" {
+		return nil, fmt.Errorf("DBHost is none")
+	}
+	if cfg.DBSchema == "" {
+		return nil, fmt.Errorf("DBSchema is none")
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.DBUser, cfg.DBPassword),
+		Host:     cfg.DBHost,
+		Path:     cfg.DBSchema,
+		RawQuery: "sslmode=disable",
+	}
+	return sql.Open("postgres", dsn.String())
+}
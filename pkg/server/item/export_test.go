@@ -0,0 +1,12 @@
+package server
+
+import (
+	"io"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/item"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+)
+
+func ExportNewItemRepository(cfg *config.Config) (repo.ItemRepository, io.Closer, error) {
+	return newItemRepository(cfg)
+}
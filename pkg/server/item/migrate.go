@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/item"
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var postgresMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_items",
+		Up: []string{
+			"CREATE SCHEMA IF NOT EXISTS itemschema",
+			"CREATE TABLE IF NOT EXISTS itemschema.items (" +
+				"id SERIAL, " +
+				"name varchar(200) DEFAULT NULL, " +
+				"description varchar(1024) DEFAULT NULL, " +
+				"price bigint DEFAULT NULL, " +
+				"PRIMARY KEY (id))",
+		},
+		Down: []string{"DROP TABLE itemschema.items"},
+	},
+	{
+		Version: 2,
+		Name:    "add_items_version",
+		Up:      []string{"ALTER TABLE itemschema.items ADD COLUMN version bigint NOT NULL DEFAULT 1"},
+		Down:    []string{"ALTER TABLE itemschema.items DROP COLUMN version"},
+	},
+}
+
+// RunMigration : run `migrate up|down|status` against the postgresql server
+func RunMigration(args []string) error {
+	cfg := config.NewConfig()
+
+	switch cfg.DBDriver {
+	case "", config.DriverPostgres:
+	default:
+		return fmt.Errorf("DBDriver %s has no schema to migrate", cfg.DBDriver)
+	}
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migration.NewMigrator(db, migration.DialectPostgres, postgresMigrations)
+	if err != nil {
+		return err
+	}
+	return migration.Run(context.Background(), m, args, os.Stdout)
+}
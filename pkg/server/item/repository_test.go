@@ -0,0 +1,55 @@
+package server_test
+
+import (
+	"reflect"
+	"testing"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/item"
+	server "github.com/smockoro/grpc-microservice-sample/pkg/server/item"
+)
+
+func TestNewItemRepository(t *testing.T) {
+	base := config.Config{
+		DBUser:     "dbuser",
+		DBPassword: "password",
+		DBHost:     "host.com",
+		DBSchema:   "schema",
+	}
+
+	cases := []struct {
+		name       string
+		driver     string
+		pkgPath    string
+		errorIsNil bool
+	}{
+		{name: "default is postgres", driver: "",
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/postgresql/item", errorIsNil: true},
+		{name: "postgres", driver: config.DriverPostgres,
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/postgresql/item", errorIsNil: true},
+		{name: "memory", driver: config.DriverMemory,
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/item", errorIsNil: true},
+		{name: "unknown driver", driver: "oracle", errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := base
+			cfg.DBDriver = c.driver
+
+			r, closer, err := server.ExportNewItemRepository(&cfg)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err != nil {
+				return
+			}
+			defer closer.Close()
+
+			if actual := reflect.TypeOf(r).Elem().PkgPath(); actual != c.pkgPath {
+				t.Errorf("want %s but actual %s", c.pkgPath, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/item"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	memrepo "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/item"
+	pgrepo "github.com/smockoro/grpc-microservice-sample/pkg/repository/postgresql/item"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/errlog"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/grpcerr"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/healthcheck"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/tlsconfig"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/item"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	"github.com/smockoro/grpc-microservice-sample/pkg/validator"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// RunServer : Component Injected and Startup gRPC Server
+func RunServer() error {
+	cfg := config.NewConfig()
+
+	authFunc, err := auth.NewAuthFunc(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure authentication: %v", err)
+	}
+	policy, err := auth.LoadPolicy(cfg.AuthzPolicyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load authorization policy: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tlsOpts, err := tlsconfig.ServerOptions(ctx, tlsconfig.Config{
+		CertFile:          cfg.TLSCertFile,
+		KeyFile:           cfg.TLSKeyFile,
+		ClientCAFile:      cfg.TLSClientCAFile,
+		RequireClientCert: cfg.TLSRequireClientCert,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", ":"+cfg.Port)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	repo, db, err := newItemRepository(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	pageTokenizer, err := lib.NewPageTokenizer([]byte(cfg.PageTokenSecret))
+	if err != nil {
+		return err
+	}
+	server := item.NewItemServiceServer(repo, pageTokenizer)
+	pinger, _ := db.(healthcheck.Pinger) // nil for the memory backend
+
+	opts := []grpc_zap.Option{}
+	zapLogger, _ := zap.NewProduction()
+	grpc_zap.ReplaceGrpcLogger(zapLogger)
+
+	s := grpc.NewServer(append(tlsOpts,
+		grpc_middleware.WithUnaryServerChain(
+			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(
+				grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			grpc_zap.UnaryServerInterceptor(zapLogger, opts...),
+			errlog.UnaryServerInterceptor(),
+			grpcerr.UnaryServerInterceptor(),
+			grpc_auth.UnaryServerInterceptor(authFunc),
+			policy.UnaryServerInterceptor(),
+			validator.UnaryServerInterceptor(),
+		),
+		grpc_middleware.WithStreamServerChain(
+			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(
+				grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			grpc_zap.StreamServerInterceptor(zapLogger, opts...),
+			errlog.StreamServerInterceptor(),
+			grpcerr.StreamServerInterceptor(),
+			grpc_auth.StreamServerInterceptor(authFunc),
+			policy.StreamServerInterceptor(),
+		),
+	)...)
+
+	api.RegisterItemServiceServer(s, server)
+	checker := healthcheck.NewChecker(pinger, healthcheck.DefaultInterval, "api.ItemService")
+	checker.Register(s)
+	reflection.Register(s)
+
+	go checker.Run(ctx)
+
+	log.Println("starting gRPC server...")
+	if err := s.Serve(lis); err != nil {
+		return fmt.Errorf("failed to serve: %v", err)
+	}
+	return nil
+}
+
+// newItemRepository : open the connection for cfg.DBDriver and build the
+// ItemRepository implementation which speaks its dialect.
+// The returned io.Closer releases the connection.
+func newItemRepository(cfg *config.Config) (repo.ItemRepository, io.Closer, error) {
+	switch cfg.DBDriver {
+	case "", config.DriverPostgres:
+		db, err := ConnectDB(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pgrepo.NewItemRepository(db), db, nil
+	case config.DriverMemory:
+		return memrepo.NewItemRepository(), nopCloser{}, nil
+	default:
+		return nil, nil, fmt.Errorf("DBDriver %s is not supported", cfg.DBDriver)
+	}
+}
+
+// nopCloser : io.Closer for backends which hold no connection
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
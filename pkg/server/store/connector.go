@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+
+	_ "github.com/go-sql-driver/mysql" // Register MySQL Driver
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // Register PostgreSQL Driver
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/store"
+)
+
+// ConnectDB : connect to mysql or postgresql server chosen by DBDriver
+func ConnectDB(cfg *config.Config) (*sqlx.DB, error) {
+	if cfg.DBUser == "" {
+		return nil, fmt.Errorf("DBUser is none")
+	}
+	if cfg.DBPassword == "" {
+		return nil, fmt.Errorf("DBPassword is none")
+	}
+	if cfg.DBHost == "" {
+		return nil, fmt.Errorf("DBHost is none")
+	}
+	if cfg.DBSchema == "" {
+		return nil, fmt.Errorf("DBSchema is none")
+	}
+
+	switch cfg.DBDriver {
+	case "", config.DriverMySQL:
+		// clientFoundRows makes RowsAffected count matched rows, so an Update
+		// which changes nothing is not mistaken for a missing store.
+		dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?clientFoundRows=true",
+			cfg.DBUser,
+			cfg.DBPassword,
+			cfg.DBHost,
+			cfg.DBSchema)
+		return sqlx.Open("mysql", dsn)
+	case config.DriverPostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.DBUser, cfg.DBPassword),
+			Host:     cfg.DBHost,
+			Path:     cfg.DBSchema,
+			RawQuery: "sslmode=disable",
+		}
+		return sqlx.Open("postgres", dsn.String())
+	default:
+		return nil, fmt.Errorf("DBDriver %s is not supported", cfg.DBDriver)
+	}
+}
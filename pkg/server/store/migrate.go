@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/store"
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var mysqlMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_stores",
+		Up: []string{
+			"CREATE TABLE IF NOT EXISTS `stores` (" +
+				"`id` bigint(20) NOT NULL AUTO_INCREMENT, " +
+				"`name` varchar(200) DEFAULT NULL, " +
+				"`mail` varchar(200) DEFAULT NULL, " +
+				"`address` varchar(1024) DEFAULT NULL, " +
+				"PRIMARY KEY (`id`), " +
+				"UNIQUE KEY `ID_UNIQUE` (`id`))",
+		},
+		Down: []string{"DROP TABLE `stores`"},
+	},
+}
+
+var postgresMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_stores",
+		Up: []string{
+			"CREATE SCHEMA IF NOT EXISTS storeschema",
+			"CREATE TABLE IF NOT EXISTS storeschema.stores (" +
+				"id SERIAL, " +
+				"name varchar(200) DEFAULT NULL, " +
+				"mail varchar(200) DEFAULT NULL, " +
+				"address varchar(1024) DEFAULT NULL, " +
+				"PRIMARY KEY (id))",
+		},
+		Down: []string{"DROP TABLE storeschema.stores"},
+	},
+}
+
+// RunMigration : run `migrate up|down|status` against the database of cfg.DBDriver
+func RunMigration(args []string) error {
+	cfg := config.NewConfig()
+
+	var dialect string
+	var migrations []migration.Migration
+	switch cfg.DBDriver {
+	case "", config.DriverMySQL:
+		dialect, migrations = migration.DialectMySQL, mysqlMigrations
+	case config.DriverPostgres:
+		dialect, migrations = migration.DialectPostgres, postgresMigrations
+	default:
+		return fmt.Errorf("DBDriver %s is not supported", cfg.DBDriver)
+	}
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migration.NewMigrator(db.DB, dialect, migrations)
+	if err != nil {
+		return err
+	}
+	return migration.Run(context.Background(), m, args, os.Stdout)
+}
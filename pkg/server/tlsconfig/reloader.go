@@ -0,0 +1,196 @@
+// Package tlsconfig builds the TLS configuration of the gRPC listeners.
+// Certificates are read from files and reloaded when the files change, so
+// rotated certificates are served without a restart.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DefaultInterval : how often Watch looks for changed files
+const DefaultInterval = 10 * time.Second
+
+// Config : files of the server certificate and, for mutual TLS, of the
+// CA which client certificates are verified with
+type Config struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// RequireClientCert rejects clients without a certificate. Otherwise
+	// a certificate is verified when given, so clients authenticating with
+	// a bearer token only can still connect.
+	RequireClientCert bool
+}
+
+// Enabled : whether cfg asks for TLS at all
+func (cfg Config) Enabled() bool {
+	return cfg.CertFile != "" || cfg.KeyFile != "" || cfg.ClientCAFile != ""
+}
+
+// Reloader : TLS configuration following the files of Config
+type Reloader struct {
+	cfg Config
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+}
+
+// NewReloader : Reloader with the files of cfg loaded
+func NewReloader(cfg Config) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, xerrors.New("both TLS certificate and key files are required")
+	}
+	if cfg.RequireClientCert && cfg.ClientCAFile == "" {
+		return nil, xerrors.New("client CA file is required to require client certificates")
+	}
+	r := &Reloader{cfg: cfg}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig : server configuration which serves the latest certificate
+// and verifies clients with the latest CA
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				NextProtos:   []string{"h2"},
+			}
+			if r.clientCAs != nil {
+				c.ClientCAs = r.clientCAs
+				c.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.cfg.RequireClientCert {
+					c.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			return c, nil
+		},
+	}
+}
+
+// Watch : reload the files every interval while they change, until ctx
+// is done. A failed reload keeps the previous certificates.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changed, err := r.changed()
+		if err != nil || !changed {
+			continue
+		}
+		if err := r.load(); err != nil {
+			log.Printf("failed to reload TLS certificates: %v", err)
+			continue
+		}
+		log.Println("reloaded TLS certificates")
+	}
+}
+
+// Reload : load the files now
+func (r *Reloader) Reload() error {
+	return r.load()
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) stat() ([]time.Time, error) {
+	files := r.files()
+	times := make([]time.Time, len(files))
+	for i, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		times[i] = fi.ModTime()
+	}
+	return times, nil
+}
+
+func (r *Reloader) changed() (bool, error) {
+	times, err := r.stat()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := range times {
+		if !times[i].Equal(r.modTimes[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Reloader) load() error {
+	times, err := r.stat()
+	if err != nil {
+		return xerrors.Errorf("failed to stat TLS files: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return xerrors.Errorf("failed to load TLS certificate: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return xerrors.Errorf("failed to read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return xerrors.New("client CA file holds no certificate")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.modTimes = times
+	return nil
+}
+
+// ServerOptions : options serving over TLS as cfg asks, none when it does
+// not enable TLS. The files are watched until ctx is done.
+func ServerOptions(ctx context.Context, cfg Config) ([]grpc.ServerOption, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	r, err := NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	go r.Watch(ctx, DefaultInterval)
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(r.TLSConfig()))}, nil
+}
@@ -0,0 +1,214 @@
+package tlsconfig_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+// issuer : CA issuing the certificates of a test
+type issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &issuer{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue : PEM certificate and key for cn, usable by servers and clients
+func (i *issuer) issue(t *testing.T, cn string, serial int64, uris ...string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, u := range uris {
+		parsed, _ := url.Parse(u)
+		tmpl.URIs = append(tmpl.URIs, parsed)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, i.cert, &key.PublicKey, i.key)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func write(t *testing.T, path string, b []byte) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatalf("err %s", err)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	ca := newIssuer(t)
+	dir := t.TempDir()
+	cfg := tlsconfig.Config{
+		CertFile:     filepath.Join(dir, "tls.crt"),
+		KeyFile:      filepath.Join(dir, "tls.key"),
+		ClientCAFile: filepath.Join(dir, "ca.crt"),
+	}
+	cert, key := ca.issue(t, "user-service", 2)
+	write(t, cfg.CertFile, cert)
+	write(t, cfg.KeyFile, key)
+	write(t, cfg.ClientCAFile, ca.pem)
+
+	r, err := tlsconfig.NewReloader(cfg)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+
+	identities := make(chan *auth.Identity, 10)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(r.TLSConfig())),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (interface{}, error) {
+			id, _ := auth.PeerIdentity(ctx)
+			identities <- id
+			return handler(ctx, req)
+		}))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	defer s.Stop()
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.pem)
+	clientCert, clientKey := ca.issue(t, "item-service", 3, "spiffe://sample/item-service")
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+
+	// call : the common name of the server certificate and the identity the
+	// server saw
+	call := func(t *testing.T, certs []tls.Certificate) (string, *auth.Identity) {
+		t.Helper()
+		creds := credentials.NewTLS(&tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: certs})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+		if err != nil {
+			t.Fatalf("Did not connect to server: %v", err)
+		}
+		defer conn.Close()
+		var p peer.Peer
+		if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p)); err != nil {
+			t.Fatalf("want nil but actual %v", err)
+		}
+		state := p.AuthInfo.(credentials.TLSInfo).State
+		return state.PeerCertificates[0].Subject.CommonName, <-identities
+	}
+
+	t.Run("client certificate", func(t *testing.T) {
+		cn, id := call(t, []tls.Certificate{pair})
+		if cn != "user-service" {
+			t.Errorf("want server certificate of user-service but actual %s", cn)
+		}
+		if id == nil || id.Names()[0] != "spiffe://sample/item-service" || id.CommonName != "item-service" {
+			t.Errorf("want identity of item-service but actual %+v", id)
+		}
+	})
+	t.Run("no client certificate", func(t *testing.T) {
+		if _, id := call(t, nil); id != nil {
+			t.Errorf("want no identity but actual %+v", id)
+		}
+	})
+	t.Run("reload", func(t *testing.T) {
+		cert, key := ca.issue(t, "user-service-rotated", 4)
+		write(t, cfg.CertFile, cert)
+		write(t, cfg.KeyFile, key)
+		if err := r.Reload(); err != nil {
+			t.Fatalf("err %s", err)
+		}
+		if cn, _ := call(t, nil); cn != "user-service-rotated" {
+			t.Errorf("want rotated server certificate but actual %s", cn)
+		}
+	})
+	t.Run("failed reload keeps certificate", func(t *testing.T) {
+		write(t, cfg.KeyFile, []byte("broken"))
+		if err := r.Reload(); err == nil {
+			t.Errorf("It is expected that err is not nil but err is nil")
+		}
+		if cn, _ := call(t, nil); cn != "user-service-rotated" {
+			t.Errorf("want rotated server certificate but actual %s", cn)
+		}
+	})
+}
+
+func TestNewReloader(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  tlsconfig.Config
+	}{
+		{name: "key missing", cfg: tlsconfig.Config{CertFile: "tls.crt"}},
+		{name: "client CA missing", cfg: tlsconfig.Config{CertFile: "tls.crt", KeyFile: "tls.key", RequireClientCert: true}},
+		{name: "files missing", cfg: tlsconfig.Config{CertFile: "missing.crt", KeyFile: "missing.key"}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := tlsconfig.NewReloader(c.cfg); err == nil {
+				t.Errorf("It is expected that err is not nil but err is nil")
+			}
+		})
+	}
+}
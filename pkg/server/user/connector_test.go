@@ -18,6 +18,24 @@ func TestConnectDB(t *testing.T) {
 			DBPassword: "password",
 			DBHost:     "host.com",
 			DBSchema:   "schema"}, errorIsNil: true},
+		{name: "mysql driver", cfg: &config.Config{
+			DBDriver:   "mysql",
+			DBUser:     "dbuser",
+			DBPassword: "password",
+			DBHost:     "host.com",
+			DBSchema:   "schema"}, errorIsNil: true},
+		{name: "postgres driver", cfg: &config.Config{
+			DBDriver:   "postgres",
+			DBUser:     "dbuser",
+			DBPassword: "password",
+			DBHost:     "host.com:5432",
+			DBSchema:   "schema"}, errorIsNil: true},
+		{name: "unknown driver", cfg: &config.Config{
+			DBDriver:   "oracle",
+			DBUser:     "dbuser",
+			DBPassword: "password",
+			DBHost:     "host.com",
+			DBSchema:   "schema"}, errorIsNil: false},
 		{name: "loss db user", cfg: &config.Config{
 			DBUser:     "",
 			DBPassword: "password",
@@ -46,9 +64,9 @@ func TestConnectDB(t *testing.T) {
 			t.Parallel()
 			if _, err := server.ConnectDB(c.cfg); (err != nil) == c.errorIsNil {
 				if c.errorIsNil {
-					t.Errorf("wanted CoonectDB(%s) is nil. but %s", c.cfg, err)
+					t.Errorf("wanted CoonectDB(%+v) is nil. but %s", c.cfg, err)
 				} else {
-					t.Errorf("wanted CoonectDB(%s) is not nil. but %s", c.cfg, err)
+					t.Errorf("wanted CoonectDB(%+v) is not nil. but %s", c.cfg, err)
 				}
 			}
 		})
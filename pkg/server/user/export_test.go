@@ -1,7 +1,18 @@
 package server
 
-import "context"
+import (
+	"io"
+	"time"
 
-func ExportTokenAuthentication(ctx context.Context) (context.Context, error) {
-	return tokenAuthentication(ctx)
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/user"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"google.golang.org/grpc"
+)
+
+func ExportNewUserRepository(cfg *config.Config) (repo.UserRepository, io.Closer, error) {
+	return newUserRepository(cfg)
+}
+
+func ExportShutdown(s *grpc.Server, timeout time.Duration) {
+	shutdown(s, timeout)
 }
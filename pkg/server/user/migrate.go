@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/user"
+	"github.com/smockoro/grpc-microservice-sample/pkg/migration"
+)
+
+var mysqlMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_users",
+		Up: []string{
+			"CREATE TABLE IF NOT EXISTS `users` (" +
+				"`id` bigint(20) NOT NULL AUTO_INCREMENT, " +
+				"`name` varchar(200) DEFAULT NULL, " +
+				"`age` bigint(20) DEFAULT NULL, " +
+				"`mail` varchar(200) DEFAULT NULL, " +
+				"`address` varchar(1024) DEFAULT NULL, " +
+				"PRIMARY KEY (`id`), " +
+				"UNIQUE KEY `ID_UNIQUE` (`id`))",
+		},
+		Down: []string{"DROP TABLE `users`"},
+	},
+	{
+		Version: 2,
+		Name:    "add_users_version",
+		Up:      []string{"ALTER TABLE `users` ADD COLUMN `version` bigint(20) NOT NULL DEFAULT 1"},
+		Down:    []string{"ALTER TABLE `users` DROP COLUMN `version`"},
+	},
+}
+
+var postgresMigrations = []migration.Migration{
+	{
+		Version: 1,
+		Name:    "create_users",
+		Up: []string{
+			"CREATE SCHEMA IF NOT EXISTS userschema",
+			"CREATE TABLE IF NOT EXISTS userschema.users (" +
+				"id SERIAL, " +
+				"name varchar(200) DEFAULT NULL, " +
+				"age int DEFAULT NULL, " +
+				"mail varchar(200) DEFAULT NULL, " +
+				"address varchar(1024) DEFAULT NULL, " +
+				"PRIMARY KEY (id))",
+		},
+		Down: []string{"DROP TABLE userschema.users"},
+	},
+	{
+		Version: 2,
+		Name:    "widen_users_age",
+		Up:      []string{"ALTER TABLE userschema.users ALTER COLUMN age TYPE bigint"},
+		Down:    []string{"ALTER TABLE userschema.users ALTER COLUMN age TYPE int"},
+	},
+	{
+		Version: 3,
+		Name:    "add_users_version",
+		Up:      []string{"ALTER TABLE userschema.users ADD COLUMN version bigint NOT NULL DEFAULT 1"},
+		Down:    []string{"ALTER TABLE userschema.users DROP COLUMN version"},
+	},
+}
+
+// RunMigration : run `migrate up|down|status` against the database of cfg.DBDriver
+func RunMigration(args []string) error {
+	cfg := config.NewConfig()
+
+	var dialect string
+	var migrations []migration.Migration
+	switch cfg.DBDriver {
+	case "", config.DriverMySQL:
+		dialect, migrations = migration.DialectMySQL, mysqlMigrations
+	case config.DriverPostgres:
+		dialect, migrations = migration.DialectPostgres, postgresMigrations
+	default:
+		return fmt.Errorf("DBDriver %s has no schema to migrate", cfg.DBDriver)
+	}
+
+	db, err := ConnectDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	m, err := migration.NewMigrator(db.DB, dialect, migrations)
+	if err != nil {
+		return err
+	}
+	return migration.Run(context.Background(), m, args, os.Stdout)
+}
@@ -0,0 +1,42 @@
+package server_test
+
+import (
+	"os"
+	"testing"
+
+	server "github.com/smockoro/grpc-microservice-sample/pkg/server/user"
+)
+
+func TestRunMigration(t *testing.T) {
+	envs := map[string]string{
+		"DB_HOST":     "localhost:3306",
+		"DB_USER":     "dbuser",
+		"DB_PASSWORD": "password",
+		"DB_SCHEMA":   "schema",
+	}
+	for key, value := range envs {
+		defer os.Setenv(key, os.Getenv(key))
+		os.Setenv(key, value)
+	}
+	defer os.Setenv("DB_DRIVER", os.Getenv("DB_DRIVER"))
+
+	cases := []struct {
+		name   string
+		driver string
+		args   []string
+	}{
+		{name: "memory has no schema", driver: "memory", args: []string{"up"}},
+		{name: "unknown driver", driver: "oracle", args: []string{"up"}},
+		{name: "mysql unknown command", driver: "mysql", args: []string{"redo"}},
+		{name: "postgres missing command", driver: "postgres", args: []string{}},
+	}
+
+	for _, c := range cases {
+		os.Setenv("DB_DRIVER", c.driver) // don't Parallel because Enviroment Value is vibration
+		t.Run(c.name, func(t *testing.T) {
+			if err := server.RunMigration(c.args); err == nil {
+				t.Errorf("error was expected while RunMigration")
+			}
+		})
+	}
+}
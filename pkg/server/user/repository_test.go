@@ -0,0 +1,57 @@
+package server_test
+
+import (
+	"reflect"
+	"testing"
+
+	config "github.com/smockoro/grpc-microservice-sample/pkg/config/user"
+	server "github.com/smockoro/grpc-microservice-sample/pkg/server/user"
+)
+
+func TestNewUserRepository(t *testing.T) {
+	base := config.Config{
+		DBUser:     "dbuser",
+		DBPassword: "password",
+		DBHost:     "host.com",
+		DBSchema:   "schema",
+	}
+
+	cases := []struct {
+		name       string
+		driver     string
+		pkgPath    string
+		errorIsNil bool
+	}{
+		{name: "default is mysql", driver: "",
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user", errorIsNil: true},
+		{name: "mysql", driver: config.DriverMySQL,
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user", errorIsNil: true},
+		{name: "postgres", driver: config.DriverPostgres,
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/postgresql/user", errorIsNil: true},
+		{name: "memory", driver: config.DriverMemory,
+			pkgPath: "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/user", errorIsNil: true},
+		{name: "unknown driver", driver: "oracle", errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := base
+			cfg.DBDriver = c.driver
+
+			r, closer, err := server.ExportNewUserRepository(&cfg)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err != nil {
+				return
+			}
+			defer closer.Close()
+
+			if actual := reflect.TypeOf(r).Elem().PkgPath(); actual != c.pkgPath {
+				t.Errorf("want %s but actual %s", c.pkgPath, actual)
+			}
+		})
+	}
+}
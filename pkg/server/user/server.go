@@ -3,76 +3,190 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
 	config "github.com/smockoro/grpc-microservice-sample/pkg/config/user"
 	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
-	repo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user"
+	memrepo "github.com/smockoro/grpc-microservice-sample/pkg/repository/memory/user"
+	mysqlrepo "github.com/smockoro/grpc-microservice-sample/pkg/repository/mysql/user"
+	pgrepo "github.com/smockoro/grpc-microservice-sample/pkg/repository/postgresql/user"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/errlog"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/grpcerr"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/healthcheck"
+	"github.com/smockoro/grpc-microservice-sample/pkg/server/tlsconfig"
 	"github.com/smockoro/grpc-microservice-sample/pkg/service/user"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"github.com/smockoro/grpc-microservice-sample/pkg/validator"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
-	"google.golang.org/grpc/status"
 )
 
-// RunServer : Component Injected and Startup gRPC Server
-func RunServer() error {
+// RunServer : Component Injected and Startup gRPC Server.
+// It serves until ctx is done or SIGINT/SIGTERM arrives, then lets
+// in-flight RPCs finish within cfg.ShutdownTimeout before cutting them off,
+// and closes the database.
+func RunServer(ctx context.Context) (err error) {
 	cfg := config.NewConfig()
 
+	authFunc, err := auth.NewAuthFunc(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure authentication: %v", err)
+	}
+	policy, err := auth.LoadPolicy(cfg.AuthzPolicyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load authorization policy: %v", err)
+	}
+	tlsOpts, err := tlsconfig.ServerOptions(ctx, tlsconfig.Config{
+		CertFile:          cfg.TLSCertFile,
+		KeyFile:           cfg.TLSKeyFile,
+		ClientCAFile:      cfg.TLSClientCAFile,
+		RequireClientCert: cfg.TLSRequireClientCert,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", ":"+cfg.Port)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		return fmt.Errorf("failed to listen: %v", err)
 	}
 
-	db, err := ConnectDB(cfg)
+	repo, db, err := newUserRepository(cfg)
 	if err != nil {
+		lis.Close()
 		return fmt.Errorf("failed to open database: %v", err)
 	}
-	defer db.Close()
+	defer func() {
+		if cerr := db.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close database: %v", cerr)
+		}
+	}()
 
 	stackTracer := lib.NewStackTracer()
-	repo := repo.NewUserRepository(db)
-	server := user.NewUserServiceServer(repo, stackTracer)
+	pageTokenizer, err := lib.NewPageTokenizer([]byte(cfg.PageTokenSecret))
+	if err != nil {
+		lis.Close()
+		return err
+	}
+	server := user.NewUserServiceServer(repo, stackTracer, pageTokenizer)
+	pinger, _ := db.(healthcheck.Pinger) // nil for the memory backend
 
 	opts := []grpc_zap.Option{}
 	zapLogger, _ := zap.NewProduction()
 	grpc_zap.ReplaceGrpcLogger(zapLogger)
 
-	s := grpc.NewServer(
+	s := grpc.NewServer(append(tlsOpts,
 		grpc_middleware.WithUnaryServerChain(
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(
 				grpc_ctxtags.CodeGenRequestFieldExtractor)),
 			grpc_zap.UnaryServerInterceptor(zapLogger, opts...),
-			grpc_auth.UnaryServerInterceptor(tokenAuthentication),
+			errlog.UnaryServerInterceptor(),
+			grpcerr.UnaryServerInterceptor(),
+			grpc_auth.UnaryServerInterceptor(authFunc),
+			policy.UnaryServerInterceptor(),
+			validator.UnaryServerInterceptor(),
+		),
+		grpc_middleware.WithStreamServerChain(
+			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(
+				grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			grpc_zap.StreamServerInterceptor(zapLogger, opts...),
+			errlog.StreamServerInterceptor(),
+			grpcerr.StreamServerInterceptor(),
+			grpc_auth.StreamServerInterceptor(authFunc),
+			policy.StreamServerInterceptor(),
 		),
-	)
+	)...)
 
 	api.RegisterUserServiceServer(s, server)
+	checker := healthcheck.NewChecker(pinger, healthcheck.DefaultInterval, "api.UserService")
+	checker.Register(s)
 	reflection.Register(s)
 
-	log.Println("starting gRPC server...")
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
-		return err
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go checker.Run(ctx)
+
+	served := make(chan error, 1)
+	go func() {
+		log.Println("starting gRPC server...")
+		served <- s.Serve(lis)
+	}()
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("failed to serve: %v", err)
+	case <-sigs:
+	case <-ctx.Done():
 	}
-	return nil
+	cancel()
+
+	checker.Drain()
+	log.Printf("shutting down gRPC server, draining for up to %v...", cfg.ShutdownTimeout)
+	shutdown(s, cfg.ShutdownTimeout)
+	return <-served
 }
 
-func tokenAuthentication(ctx context.Context) (context.Context, error) {
-	token, err := grpc_auth.AuthFromMD(ctx, "bearer")
-	if err != nil {
-		return nil, err
+// shutdown : GracefulStop s, falling back to Stop when in-flight RPCs
+// are still running after timeout
+func shutdown(s *grpc.Server, timeout time.Duration) {
+	drained := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		log.Println("drain timed out, closing remaining connections")
+		s.Stop()
+		<-drained
 	}
-	if token != "sample_token" {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+}
+
+// newUserRepository : open the connection for cfg.DBDriver and build the
+// UserRepository implementation which speaks its dialect.
+// The returned io.Closer releases the connection.
+func newUserRepository(cfg *config.Config) (repo.UserRepository, io.Closer, error) {
+	switch cfg.DBDriver {
+	case "", config.DriverMySQL:
+		db, err := ConnectDB(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return mysqlrepo.NewUserRepository(db), db, nil
+	case config.DriverPostgres:
+		db, err := ConnectDB(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pgrepo.NewUserRepository(db.DB), db, nil
+	case config.DriverMemory:
+		return memrepo.NewUserRepository(), nopCloser{}, nil
+	default:
+		return nil, nil, fmt.Errorf("DBDriver %s is not supported", cfg.DBDriver)
 	}
-	newCtx := context.WithValue(ctx, "authentication", "ok")
-	return newCtx, nil
 }
+
+// nopCloser : io.Closer for backends which hold no connection
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
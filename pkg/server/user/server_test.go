@@ -3,19 +3,40 @@ package server_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	userpb "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
 	server "github.com/smockoro/grpc-microservice-sample/pkg/server/user"
+	"github.com/smockoro/grpc-microservice-sample/testdata/token"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestRunServer(t *testing.T) {
-	go server.RunServer()
+	os.Setenv("JWT_SECRET", token.Secret)
+	defer os.Setenv("JWT_SECRET", "")
+	os.Setenv("AUTHZ_POLICY_FILE", token.Policy(t, token.AllowAll))
+	defer os.Setenv("AUTHZ_POLICY_FILE", "")
+	bearer := "bearer " + token.Sign(t, jwt.MapClaims{"sub": "1", "roles": []string{auth.AdminRole}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.RunServer(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-stopped; err != nil {
+			t.Errorf("It is expected that RunServer returns nil on shutdown but %v", err)
+		}
+	}()
 	time.Sleep(1 * time.Second) // Server Start uping
 
 	conn, err := grpc.Dial("localhost:"+os.Getenv("GRPC_PORT"), grpc.WithInsecure())
@@ -50,7 +71,7 @@ func TestRunServer(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
 			_, err := client.Create(ctx, &userpb.CreateUserRequest{
 				User: &userpb.User{
 					Name:    "Bob",
@@ -64,6 +85,20 @@ func TestRunServer(t *testing.T) {
 				t.Errorf("It is expected that err is nil but err is not nil: %v", err)
 			}
 		}},
+		{name: "Health_NotAuthorizationHeader", f: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			resp, err := healthpb.NewHealthClient(conn).Check(ctx,
+				&healthpb.HealthCheckRequest{Service: "api.UserService"})
+
+			if err != nil {
+				t.Fatalf("It is expected that err is nil but err is not nil: %v", err)
+			}
+			if resp.Status != healthpb.HealthCheckResponse_SERVING {
+				t.Errorf("It is expected that status is SERVING but %v", resp.Status)
+			}
+		}},
 		{name: "GetAll_NotAuthorizationHeader", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
@@ -78,13 +113,51 @@ func TestRunServer(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
 			_, err := client.GetAll(ctx, &userpb.GetAllUserRequest{})
 
 			if err != nil {
 				t.Errorf("It is expected that err is nil but err is not nil: %v", err)
 			}
 		}},
+		{name: "ListUsers_NotAuthorizationHeader", f: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			stream, err := client.ListUsers(ctx, &userpb.ListUsersRequest{})
+			if err == nil {
+				_, err = stream.Recv()
+			}
+
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("It is expected that err is Unauthenticated but err is %v", err)
+			}
+		}},
+		{name: "ListUsers_AuthorizationHeader", f: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
+			stream, err := client.ListUsers(ctx, &userpb.ListUsersRequest{})
+			if err != nil {
+				t.Fatalf("It is expected that err is nil but err is not nil: %v", err)
+			}
+
+			n := 0
+			for {
+				_, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("It is expected that err is nil but err is not nil: %v", err)
+				}
+				n++
+			}
+			if n == 0 {
+				t.Errorf("It is expected that users are streamed but none is received")
+			}
+		}},
 		{name: "Update_NotAuthrizationHeader", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
@@ -108,7 +181,7 @@ func TestRunServer(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
 			id := testGetID(t, client)
 			_, err := client.Update(ctx, &userpb.UpdateUserRequest{
 				User: &userpb.User{
@@ -139,7 +212,7 @@ func TestRunServer(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
 			id := testGetID(t, client)
 			_, err := client.Get(ctx, &userpb.GetUserRequest{Id: id})
 
@@ -147,6 +220,19 @@ func TestRunServer(t *testing.T) {
 				t.Errorf("It is expected that err is nil but err is not nil: %v", err)
 			}
 		}},
+		{name: "Get_OtherUsersToken", f: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			id := testGetID(t, client)
+			other := token.Sign(t, jwt.MapClaims{"sub": fmt.Sprint(id + 1)})
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer "+other)
+			_, err := client.Get(ctx, &userpb.GetUserRequest{Id: id})
+
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("It is expected that err is PermissionDenied but err is %v", err)
+			}
+		}},
 		{name: "Delete_NotAuthrizationHeader", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
@@ -162,7 +248,7 @@ func TestRunServer(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
 
-			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+			ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", bearer)
 			id := testGetID(t, client)
 			_, err := client.Delete(ctx, &userpb.DeleteUserRequest{Id: id})
 
@@ -182,7 +268,8 @@ func testGetID(t *testing.T, client userpb.UserServiceClient) int64 {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer sample_token")
+	ctx = metadata.AppendToOutgoingContext(ctx, "Authorization", "bearer "+token.Sign(t,
+		jwt.MapClaims{"sub": "1", "roles": []string{auth.AdminRole}}))
 	resp, err := client.GetAll(ctx, &userpb.GetAllUserRequest{})
 
 	if err != nil {
@@ -193,6 +280,11 @@ func testGetID(t *testing.T, client userpb.UserServiceClient) int64 {
 }
 
 func TestTokenAuthentication(t *testing.T) {
+	authFunc, err := auth.NewAuthFunc(auth.Config{Secret: token.Secret})
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+
 	cases := []struct {
 		name string
 		f    func(t *testing.T)
@@ -200,7 +292,7 @@ func TestTokenAuthentication(t *testing.T) {
 		{name: "No authorization Header", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
-			_, err := server.ExportTokenAuthentication(ctx)
+			_, err := authFunc(ctx)
 
 			if err == nil {
 				t.Errorf("It is expected that err is not nil(auth error) but err is nil")
@@ -211,7 +303,7 @@ func TestTokenAuthentication(t *testing.T) {
 			defer cancel()
 			ctx = ctxWithToken(ctx, "bearer", "")
 
-			_, err := server.ExportTokenAuthentication(ctx)
+			_, err := authFunc(ctx)
 			if err == nil {
 				t.Errorf("It is expected that err is not nil(auth error) but err is nil")
 			}
@@ -219,9 +311,19 @@ func TestTokenAuthentication(t *testing.T) {
 		{name: "Authorization Header is Bad Token", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
-			ctx = ctxWithToken(ctx, "bearer", "bad_token")
+			ctx = ctxWithToken(ctx, "bearer", "sample_token")
 
-			_, err := server.ExportTokenAuthentication(ctx)
+			_, err := authFunc(ctx)
+			if err == nil {
+				t.Errorf("It is expected that err is not nil(auth error) but err is nil")
+			}
+		}},
+		{name: "Authorization Header is Expired Token", f: func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			ctx = ctxWithToken(ctx, "bearer", token.Sign(t, jwt.MapClaims{"sub": "1", "exp": time.Now().Add(-time.Hour).Unix()}))
+
+			_, err := authFunc(ctx)
 			if err == nil {
 				t.Errorf("It is expected that err is not nil(auth error) but err is nil")
 			}
@@ -229,13 +331,15 @@ func TestTokenAuthentication(t *testing.T) {
 		{name: "Authorization Header is Ok", f: func(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
-			ctx = ctxWithToken(ctx, "bearer", "sample_token")
-			t.Logf("%v", ctx)
+			ctx = ctxWithToken(ctx, "bearer", token.Sign(t, jwt.MapClaims{"sub": "1"}))
 
-			_, err := server.ExportTokenAuthentication(ctx)
+			ctx, err := authFunc(ctx)
 			if err != nil {
 				t.Errorf("It is expected that err is nil but err is %v", err)
 			}
+			if claims, ok := auth.FromContext(ctx); !ok || claims.Subject != "1" {
+				t.Errorf("It is expected that claims of subject 1 are in the context but %v", claims)
+			}
 		}},
 	}
 
@@ -0,0 +1,85 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	userpb "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	server "github.com/smockoro/grpc-microservice-sample/pkg/server/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blockingServer : Get holds the RPC until release is closed or the
+// connection goes away
+type blockingServer struct {
+	userpb.UserServiceServer
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingServer) Get(ctx context.Context, req *userpb.GetUserRequest) (*userpb.GetUserResponse, error) {
+	close(s.entered)
+	select {
+	case <-s.release:
+		return &userpb.GetUserResponse{User: &userpb.User{Id: req.Id}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	cases := []struct {
+		name    string
+		release time.Duration
+		timeout time.Duration
+		code    codes.Code
+	}{
+		{name: "in-flight RPC finishes within the timeout", release: 50 * time.Millisecond,
+			timeout: 5 * time.Second, code: codes.OK},
+		{name: "blocked RPC is cut off after the timeout", release: time.Hour,
+			timeout: 100 * time.Millisecond, code: codes.Unavailable},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocking := &blockingServer{entered: make(chan struct{}), release: make(chan struct{})}
+			s := grpc.NewServer()
+			userpb.RegisterUserServiceServer(s, blocking)
+			go s.Serve(lis)
+
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			called := make(chan error, 1)
+			go func() {
+				_, err := userpb.NewUserServiceClient(conn).Get(context.Background(), &userpb.GetUserRequest{Id: 7})
+				called <- err
+			}()
+			<-blocking.entered
+			timer := time.AfterFunc(c.release, func() { close(blocking.release) })
+			defer timer.Stop()
+
+			start := time.Now()
+			server.ExportShutdown(s, c.timeout)
+			if elapsed := time.Since(start); elapsed > c.timeout+time.Second {
+				t.Errorf("want shutdown within %v but it took %v", c.timeout, elapsed)
+			}
+			if err := <-called; status.Code(err) != c.code {
+				t.Errorf("want %v but actual %v", c.code, err)
+			}
+		})
+	}
+}
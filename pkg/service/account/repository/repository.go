@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+)
+
+type AccountRepository interface {
+	Insert(context.Context, *api.Account) (int64, error)
+	SelectByID(context.Context, int64) (*api.Account, error)
+	SelectAll(context.Context, Page) ([]*api.Account, error)
+	Update(context.Context, *api.Account) (int64, error)
+	Delete(context.Context, int64) (int64, error)
+}
+
+// Page : keyset window of SelectAll.
+// It holds the accounts whose id is greater than AfterID in id order, at most Limit of them.
+type Page struct {
+	AfterID int64
+	Limit   int
+}
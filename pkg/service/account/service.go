@@ -0,0 +1,84 @@
+package account
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/account/repository"
+)
+
+type server struct {
+	repo          repo.AccountRepository
+	stackTracer   lib.StackTracer
+	pageTokenizer lib.PageTokenizer
+}
+
+// NewAccountServiceServer : Inject AccountService
+func NewAccountServiceServer(repo repo.AccountRepository, stackTracer lib.StackTracer, pageTokenizer lib.PageTokenizer) api.AccountServiceServer {
+	return &server{
+		repo:          repo,
+		stackTracer:   stackTracer,
+		pageTokenizer: pageTokenizer,
+	}
+}
+
+func (s *server) Create(ctx context.Context, req *api.CreateAccountRequest) (*api.CreateAccountResponse, error) {
+	id, err := s.repo.Insert(ctx, req.Account)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't create account", err)
+	}
+
+	return &api.CreateAccountResponse{Id: id}, nil
+}
+
+func (s *server) Get(ctx context.Context, req *api.GetAccountRequest) (*api.GetAccountResponse, error) {
+	account, err := s.repo.SelectByID(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get account by id", err)
+	}
+
+	return &api.GetAccountResponse{Account: account}, nil
+}
+
+func (s *server) Update(ctx context.Context, req *api.UpdateAccountRequest) (*api.UpdateAccountResponse, error) {
+	updated, err := s.repo.Update(ctx, req.Account)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't update account", err)
+	}
+
+	return &api.UpdateAccountResponse{Updated: updated}, nil
+}
+
+func (s *server) Delete(ctx context.Context, req *api.DeleteAccountRequest) (*api.DeleteAccountResponse, error) {
+	deleted, err := s.repo.Delete(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't delete account", err)
+	}
+
+	return &api.DeleteAccountResponse{Deleted: deleted}, nil
+}
+
+func (s *server) GetAll(ctx context.Context, req *api.GetAllAccountRequest) (*api.GetAllAccountResponse, error) {
+	afterID, size, err := lib.PageByID(s.pageTokenizer, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't read the page of account list", err)
+	}
+
+	// one more row tells whether a next page exists
+	accounts, err := s.repo.SelectAll(ctx, repo.Page{AfterID: afterID, Limit: size + 1})
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get all account list", err)
+	}
+
+	res := &api.GetAllAccountResponse{Accounts: accounts}
+	if len(accounts) > size {
+		res.Accounts = accounts[:size]
+		res.NextPageToken, err = lib.NextPageByID(s.pageTokenizer, res.Accounts[size-1].AccountId)
+		if err != nil {
+			return nil, s.stackTracer.Wrap("can't issue the next page of account list", err)
+		}
+	}
+
+	return res, nil
+}
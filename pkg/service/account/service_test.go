@@ -0,0 +1,198 @@
+package account_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	srv "github.com/smockoro/grpc-microservice-sample/pkg/service/account"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/account/repository"
+	mock "github.com/smockoro/grpc-microservice-sample/testdata/mock/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var pageTokenizer, _ = lib.NewPageTokenizer([]byte("secret"))
+
+func TestNewAccountServiceServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	if reflect.TypeOf(s).String() != "*account.server" {
+		t.Errorf("want %s but actual %s", "*account.server", reflect.TypeOf(s))
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	cases := []struct {
+		name       string
+		account    *api.Account
+		errorIsNil bool
+	}{
+		{name: "no lost data", account: &api.Account{Date: "2019-08-01", StoreId: 1, Details: []*api.Account_Detail{&api.Account_Detail{ItemId: 1}}}, errorIsNil: true},
+		{name: "details are lost", account: &api.Account{Date: "2019-08-01", StoreId: 1}, errorIsNil: true},
+		{name: "return err", account: &api.Account{}, errorIsNil: false},
+	}
+
+	ctx := context.Background()
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if c.errorIsNil {
+				repo.EXPECT().Insert(ctx, c.account).Return(int64(1), nil)
+			} else {
+				repo.EXPECT().Insert(ctx, c.account).Return(int64(-1), fmt.Errorf("Error"))
+			}
+			res, err := s.Create(ctx, &api.CreateAccountRequest{Account: c.account})
+			if (err == nil) != c.errorIsNil {
+				t.Errorf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err == nil && res.Id != 1 {
+				t.Errorf("want %d actual %d", 1, res.Id)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	account := &api.Account{AccountId: 1, Date: "2019-08-01", StoreId: 1}
+	repo.EXPECT().SelectByID(ctx, int64(1)).Return(account, nil)
+	res, err := s.Get(ctx, &api.GetAccountRequest{Id: 1})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if res.Account != account {
+		t.Errorf("want %s actual %s", account, res.Account)
+	}
+
+	repo.EXPECT().SelectByID(ctx, int64(2)).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.Get(ctx, &api.GetAccountRequest{Id: 2}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	account := &api.Account{AccountId: 1, Date: "2019-08-01", StoreId: 1}
+	repo.EXPECT().Update(ctx, account).Return(int64(1), nil)
+	if _, err := s.Update(ctx, &api.UpdateAccountRequest{Account: account}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Update(ctx, &api.Account{}).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Update(ctx, &api.UpdateAccountRequest{Account: &api.Account{}}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	repo.EXPECT().Delete(ctx, int64(1)).Return(int64(1), nil)
+	if _, err := s.Delete(ctx, &api.DeleteAccountRequest{Id: 1}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Delete(ctx, int64(0)).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Delete(ctx, &api.DeleteAccountRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	accounts := []*api.Account{
+		&api.Account{AccountId: 1, Date: "2019-08-01", StoreId: 1},
+		&api.Account{AccountId: 2, Date: "2019-08-02", StoreId: 2},
+	}
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(accounts, nil)
+	res, err := s.GetAll(ctx, &api.GetAllAccountRequest{})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if len(res.Accounts) != len(accounts) {
+		t.Errorf("want %d actual %d", len(accounts), len(res.Accounts))
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.GetAll(ctx, &api.GetAllAccountRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockAccountRepository(ctrl)
+	s := srv.NewAccountServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+	rows := []*api.Account{
+		&api.Account{AccountId: 1, Date: "2019-08-01", StoreId: 3},
+		&api.Account{AccountId: 2, Date: "2019-08-02", StoreId: 3},
+		&api.Account{AccountId: 3, Date: "2019-08-03", StoreId: 4},
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 0, Limit: 3}).Return(rows, nil)
+	first, err := s.GetAll(ctx, &api.GetAllAccountRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(first.Accounts) != 2 || first.NextPageToken == "" {
+		t.Fatalf("want 2 rows and a next page token but actual %v", first)
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 2, Limit: 3}).Return(rows[2:], nil)
+	last, err := s.GetAll(ctx, &api.GetAllAccountRequest{PageSize: 2, PageToken: first.NextPageToken})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(last.Accounts) != 1 || last.Accounts[0].AccountId != 3 || last.NextPageToken != "" {
+		t.Errorf("want the last row without a next page token but actual %v", last)
+	}
+
+	if _, err := s.GetAll(ctx, &api.GetAllAccountRequest{PageSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+	if _, err := s.GetAll(ctx, &api.GetAllAccountRequest{PageToken: "forged"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+}
@@ -0,0 +1,84 @@
+package book
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/book/repository"
+)
+
+type server struct {
+	repo          repo.BookRepository
+	stackTracer   lib.StackTracer
+	pageTokenizer lib.PageTokenizer
+}
+
+// NewBookServiceServer : Inject BookService
+func NewBookServiceServer(repo repo.BookRepository, stackTracer lib.StackTracer, pageTokenizer lib.PageTokenizer) api.BookServiceServer {
+	return &server{
+		repo:          repo,
+		stackTracer:   stackTracer,
+		pageTokenizer: pageTokenizer,
+	}
+}
+
+func (s *server) Create(ctx context.Context, req *api.CreateRequest) (*api.CreateResponse, error) {
+	id, err := s.repo.Insert(ctx, req.Book)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't create book", err)
+	}
+
+	return &api.CreateResponse{Id: id}, nil
+}
+
+func (s *server) Get(ctx context.Context, req *api.GetRequest) (*api.GetResponse, error) {
+	book, err := s.repo.SelectByID(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get book by id", err)
+	}
+
+	return &api.GetResponse{Book: book}, nil
+}
+
+func (s *server) Update(ctx context.Context, req *api.UpdateRequest) (*api.UpdateResponse, error) {
+	updated, err := s.repo.Update(ctx, req.Book)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't update book", err)
+	}
+
+	return &api.UpdateResponse{Updated: updated}, nil
+}
+
+func (s *server) Delete(ctx context.Context, req *api.DeleteRequest) (*api.DeleteResponse, error) {
+	deleted, err := s.repo.Delete(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't delete book", err)
+	}
+
+	return &api.DeleteResponse{Deleted: deleted}, nil
+}
+
+func (s *server) GetAll(ctx context.Context, req *api.GetAllRequest) (*api.GetAllResponse, error) {
+	afterID, size, err := lib.PageByID(s.pageTokenizer, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't read the page of book list", err)
+	}
+
+	// one more row tells whether a next page exists
+	books, err := s.repo.SelectAll(ctx, repo.Page{AfterID: afterID, Limit: size + 1})
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get all book list", err)
+	}
+
+	res := &api.GetAllResponse{Books: books}
+	if len(books) > size {
+		res.Books = books[:size]
+		res.NextPageToken, err = lib.NextPageByID(s.pageTokenizer, res.Books[size-1].Id)
+		if err != nil {
+			return nil, s.stackTracer.Wrap("can't issue the next page of book list", err)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,198 @@
+package book_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	srv "github.com/smockoro/grpc-microservice-sample/pkg/service/book"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/book/repository"
+	mock "github.com/smockoro/grpc-microservice-sample/testdata/mock/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var pageTokenizer, _ = lib.NewPageTokenizer([]byte("secret"))
+
+func TestNewBookServiceServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	if reflect.TypeOf(s).String() != "*book.server" {
+		t.Errorf("want %s but actual %s", "*book.server", reflect.TypeOf(s))
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	cases := []struct {
+		name       string
+		book       *api.Book
+		errorIsNil bool
+	}{
+		{name: "no lost data", book: &api.Book{Title: "Go", Author: "Bob", Description: "gRPC", Pages: 320, Price: 3200}, errorIsNil: true},
+		{name: "title is lost", book: &api.Book{Author: "Bob", Pages: 320, Price: 3200}, errorIsNil: true},
+		{name: "return err", book: &api.Book{}, errorIsNil: false},
+	}
+
+	ctx := context.Background()
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if c.errorIsNil {
+				repo.EXPECT().Insert(ctx, c.book).Return(int64(1), nil)
+			} else {
+				repo.EXPECT().Insert(ctx, c.book).Return(int64(-1), fmt.Errorf("Error"))
+			}
+			res, err := s.Create(ctx, &api.CreateRequest{Book: c.book})
+			if (err == nil) != c.errorIsNil {
+				t.Errorf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err == nil && res.Id != 1 {
+				t.Errorf("want %d actual %d", 1, res.Id)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	book := &api.Book{Id: 1, Title: "Go", Author: "Bob", Pages: 320, Price: 3200}
+	repo.EXPECT().SelectByID(ctx, int64(1)).Return(book, nil)
+	res, err := s.Get(ctx, &api.GetRequest{Id: 1})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if res.Book != book {
+		t.Errorf("want %s actual %s", book, res.Book)
+	}
+
+	repo.EXPECT().SelectByID(ctx, int64(2)).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.Get(ctx, &api.GetRequest{Id: 2}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	book := &api.Book{Id: 1, Title: "Go", Author: "Bob", Pages: 320, Price: 3200}
+	repo.EXPECT().Update(ctx, book).Return(int64(1), nil)
+	if _, err := s.Update(ctx, &api.UpdateRequest{Book: book}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Update(ctx, &api.Book{}).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Update(ctx, &api.UpdateRequest{Book: &api.Book{}}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	repo.EXPECT().Delete(ctx, int64(1)).Return(int64(1), nil)
+	if _, err := s.Delete(ctx, &api.DeleteRequest{Id: 1}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Delete(ctx, int64(0)).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Delete(ctx, &api.DeleteRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	books := []*api.Book{
+		&api.Book{Id: 1, Title: "Go", Author: "Bob", Pages: 320, Price: 3200},
+		&api.Book{Id: 2, Title: "Rust", Author: "Alice", Pages: 480, Price: 4000},
+	}
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(books, nil)
+	res, err := s.GetAll(ctx, &api.GetAllRequest{})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if len(res.Books) != len(books) {
+		t.Errorf("want %d actual %d", len(books), len(res.Books))
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.GetAll(ctx, &api.GetAllRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockBookRepository(ctrl)
+	s := srv.NewBookServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+	rows := []*api.Book{
+		&api.Book{Id: 1, Title: "Go"},
+		&api.Book{Id: 2, Title: "Rust"},
+		&api.Book{Id: 3, Title: "C"},
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 0, Limit: 3}).Return(rows, nil)
+	first, err := s.GetAll(ctx, &api.GetAllRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(first.Books) != 2 || first.NextPageToken == "" {
+		t.Fatalf("want 2 rows and a next page token but actual %v", first)
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 2, Limit: 3}).Return(rows[2:], nil)
+	last, err := s.GetAll(ctx, &api.GetAllRequest{PageSize: 2, PageToken: first.NextPageToken})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(last.Books) != 1 || last.Books[0].Id != 3 || last.NextPageToken != "" {
+		t.Errorf("want the last row without a next page token but actual %v", last)
+	}
+
+	if _, err := s.GetAll(ctx, &api.GetAllRequest{PageSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+	if _, err := s.GetAll(ctx, &api.GetAllRequest{PageToken: "forged"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+}
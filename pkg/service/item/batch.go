@@ -0,0 +1,97 @@
+package item
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize : most entries a batch request can carry
+const maxBatchSize = 1000
+
+func (s *server) BatchCreateItems(ctx context.Context,
+	req *api.BatchCreateItemsRequest) (*api.BatchCreateItemsResponse, error) {
+	if err := checkBatchSize(len(req.Items)); err != nil {
+		return nil, err
+	}
+	for i, item := range req.Items {
+		if item == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("items[%d] is not set", i))
+		}
+	}
+
+	ids, err := s.repo.InsertBatch(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.BatchCreateItemsResponse{Ids: ids}, nil
+}
+
+func (s *server) BatchGetItems(ctx context.Context,
+	req *api.BatchGetItemsRequest) (*api.BatchGetItemsResponse, error) {
+	if err := checkBatchSize(len(req.Ids)); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.SelectByIDs(ctx, req.Ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]*api.Item, len(list))
+	for _, item := range list {
+		found[item.Id] = item
+	}
+	results := make([]*api.ItemResult, len(req.Ids))
+	for i, id := range req.Ids {
+		results[i] = &api.ItemResult{Id: id, Item: found[id]}
+	}
+
+	return &api.BatchGetItemsResponse{Results: results}, nil
+}
+
+func (s *server) BatchDeleteItems(ctx context.Context,
+	req *api.BatchDeleteItemsRequest) (*api.BatchDeleteItemsResponse, error) {
+	if err := checkBatchSize(len(req.Items)); err != nil {
+		return nil, err
+	}
+
+	keys := make([]repo.Key, len(req.Items))
+	seen := map[int64]bool{}
+	for i, entry := range req.Items {
+		if entry == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("items[%d] is not set", i))
+		}
+		if seen[entry.Id] {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("ID='%d' is given more than once", entry.Id))
+		}
+		seen[entry.Id] = true
+		keys[i] = repo.Key{ID: entry.Id, Version: entry.Version}
+	}
+
+	if _, err := s.repo.DeleteBatch(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	// the batch is all or nothing, so every entry deleted its row
+	deleted := make([]int64, len(keys))
+	for i := range deleted {
+		deleted[i] = 1
+	}
+
+	return &api.BatchDeleteItemsResponse{Deleted: deleted}, nil
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 || n > maxBatchSize {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("a batch takes 1 to %d entries, not %d", maxBatchSize, n))
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderFields : allow-list of order_by fields
+var orderFields = map[string]bool{
+	repo.OrderByID:    true,
+	repo.OrderByName:  true,
+	repo.OrderByPrice: true,
+}
+
+func (s *server) GetAll(ctx context.Context, req *api.GetAllItemRequest) (*api.GetAllItemResponse, error) {
+	filter, err := toFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	query := queryKey(req.Filter, order)
+	page, err := s.page(req.PageSize, req.PageToken, query)
+	if err != nil {
+		return nil, err
+	}
+
+	size := page.Limit
+	page.Limit++ // one more row tells whether a next page exists
+	items, err := s.repo.SelectAll(ctx, filter, order, page)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.GetAllItemResponse{Items: items}
+	if len(items) > size {
+		res.Items = items[:size]
+		last := res.Items[size-1]
+		res.NextPageToken, err = s.pageTokenizer.Encode(pageCursor{
+			Query:  query,
+			LastID: last.Id,
+			Name:   last.Name,
+			Price:  last.Price,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to issue next page token")
+		}
+	}
+
+	return res, nil
+}
+
+func (s *server) ListItems(req *api.ListItemsRequest, stream api.ItemService_ListItemsServer) error {
+	filter, err := toFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SelectEach(stream.Context(), filter, order, stream.Send)
+}
+
+// pageCursor : content of a page_token, the sort keys of the last item of
+// the previous page and the listing it belongs to
+type pageCursor struct {
+	Query  string `json:"q"`
+	LastID int64  `json:"last_id"`
+	Name   string `json:"name,omitempty"`
+	Price  int64  `json:"price,omitempty"`
+}
+
+// page : resolve page_size and page_token of a GetAll request to a repo.Page.
+// A token is only accepted for the filter and order it was issued for.
+func (s *server) page(size int32, token string, query string) (repo.Page, error) {
+	limit, err := lib.PageSize(size)
+	if err != nil {
+		return repo.Page{}, err
+	}
+
+	page := repo.Page{Limit: limit}
+	if token != "" {
+		var cursor pageCursor
+		if err := s.pageTokenizer.Decode(token, &cursor); err != nil {
+			return repo.Page{}, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		if cursor.Query != query {
+			return repo.Page{}, status.Error(codes.InvalidArgument,
+				"page_token was issued for another filter or order_by")
+		}
+		page.After = &api.Item{Id: cursor.LastID, Name: cursor.Name, Price: cursor.Price}
+	}
+
+	return page, nil
+}
+
+// parseOrderBy : "<field>" or "<field> asc|desc", id when empty
+func parseOrderBy(orderBy string) (repo.Order, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return repo.Order{Field: repo.OrderByID}, nil
+	}
+	if len(parts) > 2 {
+		return repo.Order{}, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("order_by %q must be \"<field>\" or \"<field> desc\"", orderBy))
+	}
+
+	order := repo.Order{Field: parts[0]}
+	if !orderFields[order.Field] {
+		return repo.Order{}, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("order_by field %s is not sortable", order.Field))
+	}
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return repo.Order{}, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("order_by direction %s must be asc or desc", parts[1]))
+		}
+	}
+
+	return order, nil
+}
+
+func toFilter(f *api.ItemFilter) (repo.Filter, error) {
+	var filter repo.Filter
+	if f == nil {
+		return filter, nil
+	}
+
+	filter.NamePrefix = f.NamePrefix
+	if f.MinPrice != nil {
+		v := f.MinPrice.Value
+		filter.MinPrice = &v
+	}
+	if f.MaxPrice != nil {
+		v := f.MaxPrice.Value
+		filter.MaxPrice = &v
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return filter, status.Error(codes.InvalidArgument, "filter.min_price must not exceed filter.max_price")
+	}
+
+	return filter, nil
+}
+
+// queryKey : identify a listing so that its page tokens can't be replayed on another one
+func queryKey(f *api.ItemFilter, order repo.Order) string {
+	if f == nil {
+		f = &api.ItemFilter{}
+	}
+	return fmt.Sprintf("%s|%s|%t", proto.CompactTextString(f), order.Field, order.Desc)
+}
@@ -9,7 +9,68 @@ import (
 type ItemRepository interface {
 	Insert(context.Context, *api.Item) (int64, error)
 	SelectByID(context.Context, int64) (*api.Item, error)
-	SelectAll(context.Context) ([]*api.Item, error)
-	Update(context.Context, *api.Item) (int64, error)
-	Delete(context.Context, int64) (int64, error)
+	SelectAll(context.Context, Filter, Order, Page) ([]*api.Item, error)
+	// SelectEach calls fn for every matching item in order while rows are
+	// read and stops at the first error of fn or ctx.
+	SelectEach(context.Context, Filter, Order, func(*api.Item) error) error
+	// Update writes the given fields of item to the row of its id,
+	// leaves the other columns as they are and increments the version.
+	// When item.Version is not 0 and the row has another version it fails
+	// with a domain.Conflict error.
+	Update(context.Context, *api.Item, []string) (int64, error)
+	// Delete removes the row of id. When version is not 0 and the row has
+	// another version it fails with a domain.Conflict error.
+	Delete(ctx context.Context, id int64, version int64) (int64, error)
+	// InsertBatch inserts items in one transaction and returns their ids in
+	// order. Either every item is inserted or none is.
+	InsertBatch(context.Context, []*api.Item) ([]int64, error)
+	// SelectByIDs returns the items of ids that exist in ascending id order.
+	SelectByIDs(context.Context, []int64) ([]*api.Item, error)
+	// DeleteBatch removes the rows of keys in one transaction. When any row
+	// is missing or has another version nothing is deleted and it fails
+	// like Delete. The ids of keys must be unique.
+	DeleteBatch(context.Context, []Key) (int64, error)
+}
+
+// Key : a row of DeleteBatch, Version 0 skips the version check
+type Key struct {
+	ID      int64
+	Version int64
+}
+
+// Fields Update can write
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldPrice       = "price"
+)
+
+// Fields an item listing can be ordered by
+const (
+	OrderByID    = "id"
+	OrderByName  = "name"
+	OrderByPrice = "price"
+)
+
+// Filter : conditions of an item listing. Zero values match every item.
+type Filter struct {
+	NamePrefix string
+	MinPrice   *int64
+	MaxPrice   *int64
+}
+
+// Order : sort key of an item listing. Ties are broken by ascending id and
+// an empty Field sorts by id.
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// Page : keyset window of SelectAll.
+// It holds the items sorted after After, at most Limit of them.
+// After is the last item of the previous page, nil for the first page;
+// only its id and Order field are read.
+type Page struct {
+	After *api.Item
+	Limit int
 }
@@ -2,18 +2,24 @@ package item
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
 	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type server struct {
-	repo repo.ItemRepository
+	repo          repo.ItemRepository
+	pageTokenizer lib.PageTokenizer
 }
 
 // NewItemServiceServer : Inject ItemService
-func NewItemServiceServer(repo repo.ItemRepository) api.ItemServiceServer {
-	return &server{repo: repo}
+func NewItemServiceServer(repo repo.ItemRepository, pageTokenizer lib.PageTokenizer) api.ItemServiceServer {
+	return &server{repo: repo, pageTokenizer: pageTokenizer}
 }
 
 func (s *server) Create(ctx context.Context, req *api.CreateItemRequest) (*api.CreateItemResponse, error) {
@@ -35,7 +41,12 @@ func (s *server) Get(ctx context.Context, req *api.GetItemRequest) (*api.GetItem
 }
 
 func (s *server) Update(ctx context.Context, req *api.UpdateItemRequest) (*api.UpdateItemResponse, error) {
-	updated, err := s.repo.Update(ctx, req.Item)
+	fields, err := updateFields(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, req.Item, fields)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +55,7 @@ func (s *server) Update(ctx context.Context, req *api.UpdateItemRequest) (*api.U
 }
 
 func (s *server) Delete(ctx context.Context, req *api.DeleteItemRequest) (*api.DeleteItemResponse, error) {
-	deleted, err := s.repo.Delete(ctx, req.Id)
+	deleted, err := s.repo.Delete(ctx, req.Id, req.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -52,11 +63,43 @@ func (s *server) Delete(ctx context.Context, req *api.DeleteItemRequest) (*api.D
 	return &api.DeleteItemResponse{Deleted: deleted}, nil
 }
 
-func (s *server) GetAll(ctx context.Context, req *api.GetAllItemRequest) (*api.GetAllItemResponse, error) {
-	items, err := s.repo.SelectAll(ctx)
-	if err != nil {
-		return nil, err
+// updatableFields : update_mask paths, in the order Update writes them
+var updatableFields = []string{repo.FieldName, repo.FieldDescription, repo.FieldPrice}
+
+// updateFields : resolve update_mask to the fields to write.
+// An unset mask or "*" writes every field.
+func updateFields(mask *field_mask.FieldMask) ([]string, error) {
+	if mask == nil || len(mask.Paths) == 0 {
+		return updatableFields, nil
+	}
+	if len(mask.Paths) == 1 && mask.Paths[0] == "*" {
+		return updatableFields, nil
 	}
 
-	return &api.GetAllItemResponse{Items: items}, nil
+	requested := map[string]bool{}
+	for _, path := range mask.Paths {
+		if !contains(updatableFields, path) {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("update_mask path %q is not an updatable field", path))
+		}
+		requested[path] = true
+	}
+
+	fields := make([]string, 0, len(requested))
+	for _, field := range updatableFields {
+		if requested[field] {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,288 @@
+package item_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	srv "github.com/smockoro/grpc-microservice-sample/pkg/service/item"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	mock "github.com/smockoro/grpc-microservice-sample/testdata/mock/repository"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAll(t *testing.T) {
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	items := []*api.Item{
+		&api.Item{Id: 1, Name: "Pen", Description: "black ink", Price: 100},
+		&api.Item{Id: 2, Name: "Notebook", Description: "A5", Price: 200},
+		&api.Item{Id: 3, Name: "Eraser", Description: "white", Price: 50},
+	}
+	// pageToken : next_page_token of the first page of req, which ends at items[1]
+	pageToken := func(req *api.GetAllItemRequest) string {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := mock.NewMockItemRepository(ctrl)
+		repo.EXPECT().SelectAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(items, nil)
+		res, err := srv.NewItemServiceServer(repo, pageTokenizer).GetAll(context.Background(), req)
+		if err != nil || res.NextPageToken == "" {
+			t.Fatalf("want next page token but actual %v, %v", res, err)
+		}
+		return res.NextPageToken
+	}
+	nextToken := pageToken(&api.GetAllItemRequest{PageSize: 2})
+	byPriceToken := pageToken(&api.GetAllItemRequest{PageSize: 2, OrderBy: "price"})
+	after := &api.Item{Id: 2, Name: "Notebook", Price: 200}
+	min, max := int64(50), int64(150)
+	byID := repository.Order{Field: repository.OrderByID}
+
+	cases := []struct {
+		name      string
+		req       *api.GetAllItemRequest
+		filter    repository.Filter
+		order     repository.Order
+		page      *repository.Page // nil when the repository must not be called
+		rows      []*api.Item
+		wantItems int
+		wantToken bool
+		wantCode  codes.Code
+	}{
+		{name: "default page size", req: &api.GetAllItemRequest{},
+			order: byID, page: &repository.Page{Limit: 51}, rows: items,
+			wantItems: 3, wantToken: false, wantCode: codes.OK},
+		{name: "first page", req: &api.GetAllItemRequest{PageSize: 2},
+			order: byID, page: &repository.Page{Limit: 3}, rows: items,
+			wantItems: 2, wantToken: true, wantCode: codes.OK},
+		{name: "next page", req: &api.GetAllItemRequest{PageSize: 2, PageToken: nextToken},
+			order: byID, page: &repository.Page{After: after, Limit: 3}, rows: items[2:],
+			wantItems: 1, wantToken: false, wantCode: codes.OK},
+		{name: "filter and order", req: &api.GetAllItemRequest{
+			Filter: &api.ItemFilter{NamePrefix: "Pen", MinPrice: &wrappers.Int64Value{Value: 50},
+				MaxPrice: &wrappers.Int64Value{Value: 150}},
+			OrderBy: "price desc"},
+			filter: repository.Filter{NamePrefix: "Pen", MinPrice: &min, MaxPrice: &max},
+			order:  repository.Order{Field: repository.OrderByPrice, Desc: true},
+			page:   &repository.Page{Limit: 51}, rows: items[:1],
+			wantItems: 1, wantToken: false, wantCode: codes.OK},
+		{name: "next page of sorted listing",
+			req:   &api.GetAllItemRequest{PageSize: 2, PageToken: byPriceToken, OrderBy: "price asc"},
+			order: repository.Order{Field: repository.OrderByPrice},
+			page:  &repository.Page{After: after, Limit: 3}, rows: items[2:],
+			wantItems: 1, wantToken: false, wantCode: codes.OK},
+		{name: "page token of another order", req: &api.GetAllItemRequest{PageToken: nextToken, OrderBy: "price"},
+			wantCode: codes.InvalidArgument},
+		{name: "unknown order field", req: &api.GetAllItemRequest{OrderBy: "description"},
+			wantCode: codes.InvalidArgument},
+		{name: "inverted price range", req: &api.GetAllItemRequest{Filter: &api.ItemFilter{
+			MinPrice: &wrappers.Int64Value{Value: 150}, MaxPrice: &wrappers.Int64Value{Value: 50}}},
+			wantCode: codes.InvalidArgument},
+		{name: "negative page size", req: &api.GetAllItemRequest{PageSize: -1},
+			wantCode: codes.InvalidArgument},
+		{name: "forged page token", req: &api.GetAllItemRequest{PageToken: "eyJsYXN0X2lkIjoyfQ.AAAA"},
+			wantCode: codes.InvalidArgument},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockItemRepository(ctrl)
+			s := srv.NewItemServiceServer(repo, pageTokenizer)
+			ctx := context.Background()
+			if c.page != nil {
+				repo.EXPECT().SelectAll(ctx, c.filter, c.order, *c.page).Return(c.rows, nil)
+			}
+
+			res, err := s.GetAll(ctx, c.req)
+			if status.Code(err) != c.wantCode {
+				t.Fatalf("want code %v but actual %v", c.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(res.Items) != c.wantItems {
+				t.Errorf("want %d items but actual %d", c.wantItems, len(res.Items))
+			}
+			if (res.NextPageToken != "") != c.wantToken {
+				t.Errorf("want next page token: %v but actual %q", c.wantToken, res.NextPageToken)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	item := &api.Item{Id: 1, Name: "Pen", Description: "black ink", Price: 100}
+	allFields := []string{repository.FieldName, repository.FieldDescription, repository.FieldPrice}
+
+	cases := []struct {
+		name     string
+		mask     *field_mask.FieldMask
+		fields   []string // nil when the repository must not be called
+		wantCode codes.Code
+	}{
+		{name: "no mask", mask: nil, fields: allFields, wantCode: codes.OK},
+		{name: "empty mask", mask: &field_mask.FieldMask{}, fields: allFields, wantCode: codes.OK},
+		{name: "wildcard", mask: &field_mask.FieldMask{Paths: []string{"*"}}, fields: allFields, wantCode: codes.OK},
+		{name: "price only", mask: &field_mask.FieldMask{Paths: []string{"price"}},
+			fields: []string{repository.FieldPrice}, wantCode: codes.OK},
+		{name: "id", mask: &field_mask.FieldMask{Paths: []string{"id"}}, wantCode: codes.InvalidArgument},
+		{name: "unknown path", mask: &field_mask.FieldMask{Paths: []string{"price", "stock"}},
+			wantCode: codes.InvalidArgument},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockItemRepository(ctrl)
+			s := srv.NewItemServiceServer(repo, pageTokenizer)
+			ctx := context.Background()
+			if c.fields != nil {
+				repo.EXPECT().Update(ctx, item, c.fields).Return(int64(1), nil)
+			}
+
+			_, err := s.Update(ctx, &api.UpdateItemRequest{Item: item, UpdateMask: c.mask})
+			if status.Code(err) != c.wantCode {
+				t.Errorf("want code %v but actual %v", c.wantCode, err)
+			}
+		})
+	}
+}
+
+type listItemsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*api.Item
+}
+
+func (s *listItemsStream) Context() context.Context { return s.ctx }
+
+func (s *listItemsStream) Send(item *api.Item) error {
+	s.sent = append(s.sent, item)
+	return nil
+}
+
+func TestListItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	repo := mock.NewMockItemRepository(ctrl)
+	s := srv.NewItemServiceServer(repo, pageTokenizer)
+	stream := &listItemsStream{ctx: context.Background()}
+	items := []*api.Item{
+		&api.Item{Id: 1, Name: "Pen", Description: "black ink", Price: 100},
+		&api.Item{Id: 2, Name: "Notebook", Description: "A5", Price: 200},
+	}
+	filter := repository.Filter{NamePrefix: "P"}
+	order := repository.Order{Field: repository.OrderByName, Desc: true}
+	repo.EXPECT().SelectEach(stream.ctx, filter, order, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ repository.Filter, _ repository.Order,
+			fn func(*api.Item) error) error {
+			for _, item := range items {
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	if err := s.ListItems(&api.ListItemsRequest{
+		Filter: &api.ItemFilter{NamePrefix: "P"}, OrderBy: "name desc"}, stream); err != nil {
+		t.Fatalf("error was not expected while ListItems: %s", err)
+	}
+	if len(stream.sent) != 2 {
+		t.Errorf("want 2 items sent but actual %d", len(stream.sent))
+	}
+
+	err := s.ListItems(&api.ListItemsRequest{OrderBy: "description"}, stream)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want code %v but actual %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestBatchGetItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockItemRepository(ctrl)
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	s := srv.NewItemServiceServer(repo, pageTokenizer)
+	ctx := context.Background()
+	pen := &api.Item{Id: 1, Name: "Pen"}
+	notebook := &api.Item{Id: 3, Name: "Notebook"}
+	repo.EXPECT().SelectByIDs(ctx, []int64{3, 2, 1}).Return([]*api.Item{pen, notebook}, nil)
+
+	res, err := s.BatchGetItems(ctx, &api.BatchGetItemsRequest{Ids: []int64{3, 2, 1}})
+	if err != nil {
+		t.Fatalf("error was not expected while BatchGetItems stats: %s", err)
+	}
+	want := []*api.ItemResult{{Id: 3, Item: notebook}, {Id: 2}, {Id: 1, Item: pen}}
+	if len(res.Results) != len(want) {
+		t.Fatalf("want %v but actual %v", want, res.Results)
+	}
+	for i := range want {
+		if res.Results[i].Id != want[i].Id || res.Results[i].Item != want[i].Item {
+			t.Errorf("want %v but actual %v", want[i], res.Results[i])
+		}
+	}
+}
+
+func TestBatchDeleteItems(t *testing.T) {
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	tooMany := make([]*api.DeleteItemRequest, 1001)
+	for i := range tooMany {
+		tooMany[i] = &api.DeleteItemRequest{Id: int64(i + 1)}
+	}
+
+	cases := []struct {
+		name     string
+		items    []*api.DeleteItemRequest
+		keys     []repository.Key // nil when the repository must not be called
+		wantCode codes.Code
+	}{
+		{name: "ids and versions", items: []*api.DeleteItemRequest{{Id: 1}, {Id: 2, Version: 3}},
+			keys: []repository.Key{{ID: 1}, {ID: 2, Version: 3}}, wantCode: codes.OK},
+		{name: "empty", wantCode: codes.InvalidArgument},
+		{name: "too many", items: tooMany, wantCode: codes.InvalidArgument},
+		{name: "duplicate id", items: []*api.DeleteItemRequest{{Id: 1}, {Id: 1, Version: 2}},
+			wantCode: codes.InvalidArgument},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockItemRepository(ctrl)
+			s := srv.NewItemServiceServer(repo, pageTokenizer)
+			ctx := context.Background()
+			if c.keys != nil {
+				repo.EXPECT().DeleteBatch(ctx, c.keys).Return(int64(len(c.keys)), nil)
+			}
+
+			res, err := s.BatchDeleteItems(ctx, &api.BatchDeleteItemsRequest{Items: c.items})
+			if status.Code(err) != c.wantCode {
+				t.Errorf("want code %v but actual %v", c.wantCode, err)
+			}
+			if err == nil && len(res.Deleted) != len(c.items) {
+				t.Errorf("want %d results but actual %v", len(c.items), res.Deleted)
+			}
+		})
+	}
+}
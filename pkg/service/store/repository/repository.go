@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+)
+
+type StoreRepository interface {
+	Insert(context.Context, *api.Store) (int64, error)
+	SelectByID(context.Context, int64) (*api.Store, error)
+	SelectAll(context.Context, Page) ([]*api.Store, error)
+	Update(context.Context, *api.Store) (int64, error)
+	Delete(context.Context, int64) (int64, error)
+}
+
+// Page : keyset window of SelectAll.
+// It holds the stores whose id is greater than AfterID in id order, at most Limit of them.
+type Page struct {
+	AfterID int64
+	Limit   int
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+)
+
+type server struct {
+	repo          repo.StoreRepository
+	stackTracer   lib.StackTracer
+	pageTokenizer lib.PageTokenizer
+}
+
+// NewStoreServiceServer : Inject StoreService
+func NewStoreServiceServer(repo repo.StoreRepository, stackTracer lib.StackTracer, pageTokenizer lib.PageTokenizer) api.StoreServiceServer {
+	return &server{
+		repo:          repo,
+		stackTracer:   stackTracer,
+		pageTokenizer: pageTokenizer,
+	}
+}
+
+func (s *server) Create(ctx context.Context, req *api.CreateStoreRequest) (*api.CreateStoreResponse, error) {
+	id, err := s.repo.Insert(ctx, req.Store)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't create store", err)
+	}
+
+	return &api.CreateStoreResponse{Id: id}, nil
+}
+
+func (s *server) Get(ctx context.Context, req *api.GetStoreRequest) (*api.GetStoreResponse, error) {
+	store, err := s.repo.SelectByID(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get store by id", err)
+	}
+
+	return &api.GetStoreResponse{Store: store}, nil
+}
+
+func (s *server) Update(ctx context.Context, req *api.UpdateStoreRequest) (*api.UpdateStoreResponse, error) {
+	updated, err := s.repo.Update(ctx, req.Store)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't update store", err)
+	}
+
+	return &api.UpdateStoreResponse{Updated: updated}, nil
+}
+
+func (s *server) Delete(ctx context.Context, req *api.DeleteStoreRequest) (*api.DeleteStoreResponse, error) {
+	deleted, err := s.repo.Delete(ctx, req.Id)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't delete store", err)
+	}
+
+	return &api.DeleteStoreResponse{Deleted: deleted}, nil
+}
+
+func (s *server) GetAll(ctx context.Context, req *api.GetAllStoreRequest) (*api.GetAllStoreResponse, error) {
+	afterID, size, err := lib.PageByID(s.pageTokenizer, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't read the page of store list", err)
+	}
+
+	// one more row tells whether a next page exists
+	stores, err := s.repo.SelectAll(ctx, repo.Page{AfterID: afterID, Limit: size + 1})
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get all store list", err)
+	}
+
+	res := &api.GetAllStoreResponse{Stores: stores}
+	if len(stores) > size {
+		res.Stores = stores[:size]
+		res.NextPageToken, err = lib.NextPageByID(s.pageTokenizer, res.Stores[size-1].Id)
+		if err != nil {
+			return nil, s.stackTracer.Wrap("can't issue the next page of store list", err)
+		}
+	}
+
+	return res, nil
+}
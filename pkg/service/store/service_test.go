@@ -0,0 +1,198 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	srv "github.com/smockoro/grpc-microservice-sample/pkg/service/store"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+	mock "github.com/smockoro/grpc-microservice-sample/testdata/mock/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var pageTokenizer, _ = lib.NewPageTokenizer([]byte("secret"))
+
+func TestNewStoreServiceServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	if reflect.TypeOf(s).String() != "*store.server" {
+		t.Errorf("want %s but actual %s", "*store.server", reflect.TypeOf(s))
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+
+	cases := []struct {
+		name       string
+		store      *api.Store
+		errorIsNil bool
+	}{
+		{name: "no lost data", store: &api.Store{Name: "Shop", Mail: "shop@sample.com", Address: "Tokyo"}, errorIsNil: true},
+		{name: "name is lost", store: &api.Store{Mail: "shop@sample.com", Address: "Tokyo"}, errorIsNil: true},
+		{name: "return err", store: &api.Store{}, errorIsNil: false},
+	}
+
+	ctx := context.Background()
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			if c.errorIsNil {
+				repo.EXPECT().Insert(ctx, c.store).Return(int64(1), nil)
+			} else {
+				repo.EXPECT().Insert(ctx, c.store).Return(int64(-1), fmt.Errorf("Error"))
+			}
+			res, err := s.Create(ctx, &api.CreateStoreRequest{Store: c.store})
+			if (err == nil) != c.errorIsNil {
+				t.Errorf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if err == nil && res.Id != 1 {
+				t.Errorf("want %d actual %d", 1, res.Id)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	store := &api.Store{Id: 1, Name: "Shop", Mail: "shop@sample.com", Address: "Tokyo"}
+	repo.EXPECT().SelectByID(ctx, int64(1)).Return(store, nil)
+	res, err := s.Get(ctx, &api.GetStoreRequest{Id: 1})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if res.Store != store {
+		t.Errorf("want %s actual %s", store, res.Store)
+	}
+
+	repo.EXPECT().SelectByID(ctx, int64(2)).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.Get(ctx, &api.GetStoreRequest{Id: 2}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	store := &api.Store{Id: 1, Name: "Shop", Mail: "shop@sample.com", Address: "Tokyo"}
+	repo.EXPECT().Update(ctx, store).Return(int64(1), nil)
+	if _, err := s.Update(ctx, &api.UpdateStoreRequest{Store: store}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Update(ctx, &api.Store{}).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Update(ctx, &api.UpdateStoreRequest{Store: &api.Store{}}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	repo.EXPECT().Delete(ctx, int64(1)).Return(int64(1), nil)
+	if _, err := s.Delete(ctx, &api.DeleteStoreRequest{Id: 1}); err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+
+	repo.EXPECT().Delete(ctx, int64(0)).Return(int64(0), fmt.Errorf("Error"))
+	if _, err := s.Delete(ctx, &api.DeleteStoreRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+
+	stores := []*api.Store{
+		&api.Store{Id: 1, Name: "Shop", Mail: "shop@sample.com", Address: "Tokyo"},
+		&api.Store{Id: 2, Name: "Market", Mail: "market@sample.com", Address: "London"},
+	}
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(stores, nil)
+	res, err := s.GetAll(ctx, &api.GetAllStoreRequest{})
+	if err != nil {
+		t.Errorf("want %s actual %s", "nil", err)
+	}
+	if len(res.Stores) != len(stores) {
+		t.Errorf("want %d actual %d", len(stores), len(res.Stores))
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{Limit: lib.DefaultPageSize + 1}).Return(nil, fmt.Errorf("Error"))
+	if _, err := s.GetAll(ctx, &api.GetAllStoreRequest{}); err == nil {
+		t.Errorf("want error actual %s", "nil")
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockStoreRepository(ctrl)
+	s := srv.NewStoreServiceServer(repo, lib.NewStackTracer(), pageTokenizer)
+	ctx := context.Background()
+	rows := []*api.Store{
+		&api.Store{Id: 1, Name: "Shop"},
+		&api.Store{Id: 2, Name: "Market"},
+		&api.Store{Id: 3, Name: "Mall"},
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 0, Limit: 3}).Return(rows, nil)
+	first, err := s.GetAll(ctx, &api.GetAllStoreRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(first.Stores) != 2 || first.NextPageToken == "" {
+		t.Fatalf("want 2 rows and a next page token but actual %v", first)
+	}
+
+	repo.EXPECT().SelectAll(ctx, repository.Page{AfterID: 2, Limit: 3}).Return(rows[2:], nil)
+	last, err := s.GetAll(ctx, &api.GetAllStoreRequest{PageSize: 2, PageToken: first.NextPageToken})
+	if err != nil {
+		t.Fatalf("want %s actual %s", "nil", err)
+	}
+	if len(last.Stores) != 1 || last.Stores[0].Id != 3 || last.NextPageToken != "" {
+		t.Errorf("want the last row without a next page token but actual %v", last)
+	}
+
+	if _, err := s.GetAll(ctx, &api.GetAllStoreRequest{PageSize: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+	if _, err := s.GetAll(ctx, &api.GetAllStoreRequest{PageToken: "forged"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want %s but actual %v", codes.InvalidArgument, err)
+	}
+}
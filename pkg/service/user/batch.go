@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize : most entries a batch request can carry
+const maxBatchSize = 1000
+
+func (s *server) BatchCreateUsers(ctx context.Context,
+	req *api.BatchCreateUsersRequest) (*api.BatchCreateUsersResponse, error) {
+	if err := checkBatchSize(len(req.Users)); err != nil {
+		return nil, err
+	}
+	for i, user := range req.Users {
+		if user == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("users[%d] is not set", i))
+		}
+	}
+
+	ids, err := s.repo.InsertBatch(ctx, req.Users)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't create users", err)
+	}
+
+	return &api.BatchCreateUsersResponse{Ids: ids}, nil
+}
+
+func (s *server) BatchGetUsers(ctx context.Context,
+	req *api.BatchGetUsersRequest) (*api.BatchGetUsersResponse, error) {
+	if err := checkBatchSize(len(req.Ids)); err != nil {
+		return nil, err
+	}
+	if err := checkOwner(ctx, req.Ids...); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.SelectByIDs(ctx, req.Ids)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get users by ids", err)
+	}
+
+	found := make(map[int64]*api.User, len(list))
+	for _, user := range list {
+		found[user.Id] = user
+	}
+	results := make([]*api.UserResult, len(req.Ids))
+	for i, id := range req.Ids {
+		results[i] = &api.UserResult{Id: id, User: found[id]}
+	}
+
+	return &api.BatchGetUsersResponse{Results: results}, nil
+}
+
+func (s *server) BatchDeleteUsers(ctx context.Context,
+	req *api.BatchDeleteUsersRequest) (*api.BatchDeleteUsersResponse, error) {
+	if err := checkBatchSize(len(req.Users)); err != nil {
+		return nil, err
+	}
+
+	keys := make([]repo.Key, len(req.Users))
+	ids := make([]int64, len(req.Users))
+	seen := map[int64]bool{}
+	for i, entry := range req.Users {
+		if entry == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("users[%d] is not set", i))
+		}
+		if seen[entry.Id] {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("ID='%d' is given more than once", entry.Id))
+		}
+		seen[entry.Id] = true
+		keys[i] = repo.Key{ID: entry.Id, Version: entry.Version}
+		ids[i] = entry.Id
+	}
+	if err := checkOwner(ctx, ids...); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.DeleteBatch(ctx, keys); err != nil {
+		return nil, s.stackTracer.Wrap("can't delete users", err)
+	}
+
+	// the batch is all or nothing, so every entry deleted its row
+	deleted := make([]int64, len(keys))
+	for i := range deleted {
+		deleted[i] = 1
+	}
+
+	return &api.BatchDeleteUsersResponse{Deleted: deleted}, nil
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 || n > maxBatchSize {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("a batch takes 1 to %d entries, not %d", maxBatchSize, n))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// orderFields : allow-list of order_by fields
+var orderFields = map[string]bool{
+	repo.OrderByID:   true,
+	repo.OrderByName: true,
+	repo.OrderByAge:  true,
+	repo.OrderByMail: true,
+}
+
+func (s *server) GetAll(ctx context.Context, req *api.GetAllUserRequest) (*api.GetAllUserResponse, error) {
+	filter, err := toFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := restrictToOwner(ctx, &filter); err != nil {
+		return nil, err
+	}
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	query := queryKey(req.Filter, order)
+	page, err := s.page(req.PageSize, req.PageToken, query)
+	if err != nil {
+		return nil, err
+	}
+
+	size := page.Limit
+	page.Limit++ // one more row tells whether a next page exists
+	users, err := s.repo.SelectAll(ctx, filter, order, page)
+	if err != nil {
+		return nil, s.stackTracer.Wrap("can't get all user list", err)
+	}
+
+	res := &api.GetAllUserResponse{Users: users}
+	if len(users) > size {
+		res.Users = users[:size]
+		last := res.Users[size-1]
+		res.NextPageToken, err = s.pageTokenizer.Encode(pageCursor{
+			Query:  query,
+			LastID: last.Id,
+			Name:   last.Name,
+			Age:    last.Age,
+			Mail:   last.Mail,
+		})
+		if err != nil {
+			return nil, s.stackTracer.Wrap("can't issue next page token", err)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *server) ListUsers(req *api.ListUsersRequest, stream api.UserService_ListUsersServer) error {
+	filter, err := toFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+	if err := restrictToOwner(stream.Context(), &filter); err != nil {
+		return err
+	}
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SelectEach(stream.Context(), filter, order, stream.Send); err != nil {
+		return s.stackTracer.Wrap("can't list users", err)
+	}
+
+	return nil
+}
+
+// pageCursor : content of a page_token, the sort keys of the last user of
+// the previous page and the listing it belongs to
+type pageCursor struct {
+	Query  string `json:"q"`
+	LastID int64  `json:"last_id"`
+	Name   string `json:"name,omitempty"`
+	Age    int64  `json:"age,omitempty"`
+	Mail   string `json:"mail,omitempty"`
+}
+
+// page : resolve page_size and page_token of a GetAll request to a repo.Page.
+// A token is only accepted for the filter and order it was issued for.
+func (s *server) page(size int32, token string, query string) (repo.Page, error) {
+	limit, err := lib.PageSize(size)
+	if err != nil {
+		return repo.Page{}, err
+	}
+
+	page := repo.Page{Limit: limit}
+	if token != "" {
+		var cursor pageCursor
+		if err := s.pageTokenizer.Decode(token, &cursor); err != nil {
+			return repo.Page{}, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		if cursor.Query != query {
+			return repo.Page{}, status.Error(codes.InvalidArgument,
+				"page_token was issued for another filter or order_by")
+		}
+		page.After = &api.User{Id: cursor.LastID, Name: cursor.Name, Age: cursor.Age, Mail: cursor.Mail}
+	}
+
+	return page, nil
+}
+
+// parseOrderBy : "<field>" or "<field> asc|desc", id when empty
+func parseOrderBy(orderBy string) (repo.Order, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return repo.Order{Field: repo.OrderByID}, nil
+	}
+	if len(parts) > 2 {
+		return repo.Order{}, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("order_by %q must be \"<field>\" or \"<field> desc\"", orderBy))
+	}
+
+	order := repo.Order{Field: parts[0]}
+	if !orderFields[order.Field] {
+		return repo.Order{}, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("order_by field %s is not sortable", order.Field))
+	}
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return repo.Order{}, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("order_by direction %s must be asc or desc", parts[1]))
+		}
+	}
+
+	return order, nil
+}
+
+func toFilter(f *api.UserFilter) (repo.Filter, error) {
+	var filter repo.Filter
+	if f == nil {
+		return filter, nil
+	}
+
+	filter.NamePrefix = f.NamePrefix
+	filter.MailDomain = f.MailDomain
+	if f.MinAge != nil {
+		v := f.MinAge.Value
+		filter.MinAge = &v
+	}
+	if f.MaxAge != nil {
+		v := f.MaxAge.Value
+		filter.MaxAge = &v
+	}
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		return filter, status.Error(codes.InvalidArgument, "filter.min_age must not exceed filter.max_age")
+	}
+
+	return filter, nil
+}
+
+// restrictToOwner : limit a listing to the caller's own user unless the
+// caller is an admin
+func restrictToOwner(ctx context.Context, filter *repo.Filter) error {
+	c, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+	if !c.admin {
+		filter.ID = &c.id
+	}
+	return nil
+}
+
+// queryKey : identify a listing so that its page tokens can't be replayed on another one
+func queryKey(f *api.UserFilter, order repo.Order) string {
+	if f == nil {
+		f = &api.UserFilter{}
+	}
+	return fmt.Sprintf("%s|%s|%t", proto.CompactTextString(f), order.Field, order.Desc)
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// caller : the user an RPC acts for. The subject of the token is the id of
+// the user it was issued to.
+type caller struct {
+	id    int64
+	admin bool
+}
+
+// callerOf : the caller of ctx, from the claims authentication verified
+func callerOf(ctx context.Context) (caller, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return caller{}, status.Error(codes.Unauthenticated, "no verified token")
+	}
+	// a client certificate is no user, whatever its name
+	if claims.Subject == "" && !claims.HasRole(auth.AdminRole) {
+		return caller{}, status.Error(codes.PermissionDenied, "the caller is no user")
+	}
+	// a subject which is not an id owns no user
+	id, _ := strconv.ParseInt(claims.Subject, 10, 64)
+	return caller{id: id, admin: claims.HasRole(auth.AdminRole)}, nil
+}
+
+// owns : whether the caller may read and modify the user of id.
+// Admins own every user.
+func (c caller) owns(id int64) bool {
+	return c.admin || (c.id > 0 && c.id == id)
+}
+
+// checkOwner : PermissionDenied unless the caller of ctx owns every user of ids.
+// It runs before the repository, so callers can't probe which ids exist.
+func checkOwner(ctx context.Context, ids ...int64) error {
+	c, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !c.owns(id) {
+			return status.Error(codes.PermissionDenied, fmt.Sprintf("ID='%d' is not yours", id))
+		}
+	}
+	return nil
+}
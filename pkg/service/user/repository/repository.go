@@ -9,7 +9,73 @@ import (
 type UserRepository interface {
 	Insert(context.Context, *api.User) (int64, error)
 	SelectByID(context.Context, int64) (*api.User, error)
-	SelectAll(context.Context) ([]*api.User, error)
-	Update(context.Context, *api.User) (int64, error)
-	Delete(context.Context, int64) (int64, error)
+	SelectAll(context.Context, Filter, Order, Page) ([]*api.User, error)
+	// SelectEach calls fn for every matching user in order while rows are
+	// read and stops at the first error of fn or ctx.
+	SelectEach(context.Context, Filter, Order, func(*api.User) error) error
+	// Update writes the given fields of user to the row of its id,
+	// leaves the other columns as they are and increments the version.
+	// When user.Version is not 0 and the row has another version it fails
+	// with a domain.Conflict error.
+	Update(context.Context, *api.User, []string) (int64, error)
+	// Delete removes the row of id. When version is not 0 and the row has
+	// another version it fails with a domain.Conflict error.
+	Delete(ctx context.Context, id int64, version int64) (int64, error)
+	// InsertBatch inserts users in one transaction and returns their ids in
+	// order. Either every user is inserted or none is.
+	InsertBatch(context.Context, []*api.User) ([]int64, error)
+	// SelectByIDs returns the users of ids that exist in ascending id order.
+	SelectByIDs(context.Context, []int64) ([]*api.User, error)
+	// DeleteBatch removes the rows of keys in one transaction. When any row
+	// is missing or has another version nothing is deleted and it fails
+	// like Delete. The ids of keys must be unique.
+	DeleteBatch(context.Context, []Key) (int64, error)
+}
+
+// Key : a row of DeleteBatch, Version 0 skips the version check
+type Key struct {
+	ID      int64
+	Version int64
+}
+
+// Fields Update can write
+const (
+	FieldName    = "name"
+	FieldAge     = "age"
+	FieldMail    = "mail"
+	FieldAddress = "address"
+)
+
+// Fields a user listing can be ordered by
+const (
+	OrderByID   = "id"
+	OrderByName = "name"
+	OrderByAge  = "age"
+	OrderByMail = "mail"
+)
+
+// Filter : conditions of a user listing. Zero values match every user.
+type Filter struct {
+	// ID restricts the listing to the user of that id
+	ID         *int64
+	NamePrefix string
+	MinAge     *int64
+	MaxAge     *int64
+	MailDomain string
+}
+
+// Order : sort key of a user listing. Ties are broken by ascending id and
+// an empty Field sorts by id.
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// Page : keyset window of SelectAll.
+// It holds the users sorted after After, at most Limit of them.
+// After is the last user of the previous page, nil for the first page;
+// only its id and Order field are read.
+type Page struct {
+	After *api.User
+	Limit int
 }
@@ -2,21 +2,28 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
 	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
 	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type server struct {
-	repo        repo.UserRepository
-	stackTracer lib.StackTracer
+	repo          repo.UserRepository
+	stackTracer   lib.StackTracer
+	pageTokenizer lib.PageTokenizer
 }
 
-func NewUserServiceServer(repo repo.UserRepository, stackTracer lib.StackTracer) api.UserServiceServer {
+func NewUserServiceServer(repo repo.UserRepository, stackTracer lib.StackTracer,
+	pageTokenizer lib.PageTokenizer) api.UserServiceServer {
 	return &server{
-		repo:        repo,
-		stackTracer: stackTracer,
+		repo:          repo,
+		stackTracer:   stackTracer,
+		pageTokenizer: pageTokenizer,
 	}
 }
 
@@ -30,6 +37,10 @@ func (s *server) Create(ctx context.Context, req *api.CreateUserRequest) (*api.C
 }
 
 func (s *server) Get(ctx context.Context, req *api.GetUserRequest) (*api.GetUserResponse, error) {
+	if err := checkOwner(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.SelectByID(ctx, req.Id)
 	if err != nil {
 		return nil, s.stackTracer.Wrap("can't get user by id", err)
@@ -39,7 +50,16 @@ func (s *server) Get(ctx context.Context, req *api.GetUserRequest) (*api.GetUser
 }
 
 func (s *server) Update(ctx context.Context, req *api.UpdateUserRequest) (*api.UpdateUserResponse, error) {
-	updated, err := s.repo.Update(ctx, req.User)
+	if err := checkOwner(ctx, req.GetUser().GetId()); err != nil {
+		return nil, err
+	}
+
+	fields, err := updateFields(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, req.User, fields)
 	if err != nil {
 		return nil, s.stackTracer.Wrap("can't update user profile", err)
 	}
@@ -48,7 +68,11 @@ func (s *server) Update(ctx context.Context, req *api.UpdateUserRequest) (*api.U
 }
 
 func (s *server) Delete(ctx context.Context, req *api.DeleteUserRequest) (*api.DeleteUserResponse, error) {
-	deleted, err := s.repo.Delete(ctx, req.Id)
+	if err := checkOwner(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.repo.Delete(ctx, req.Id, req.Version)
 	if err != nil {
 		return nil, s.stackTracer.Wrap("can't delete user", err)
 	}
@@ -56,11 +80,43 @@ func (s *server) Delete(ctx context.Context, req *api.DeleteUserRequest) (*api.D
 	return &api.DeleteUserResponse{Deleted: deleted}, nil
 }
 
-func (s *server) GetAll(ctx context.Context, req *api.GetAllUserRequest) (*api.GetAllUserResponse, error) {
-	users, err := s.repo.SelectAll(ctx)
-	if err != nil {
-		return nil, s.stackTracer.Wrap("can't get all user list", err)
+// updatableFields : update_mask paths, in the order Update writes them
+var updatableFields = []string{repo.FieldName, repo.FieldAge, repo.FieldMail, repo.FieldAddress}
+
+// updateFields : resolve update_mask to the fields to write.
+// An unset mask or "*" writes every field.
+func updateFields(mask *field_mask.FieldMask) ([]string, error) {
+	if mask == nil || len(mask.Paths) == 0 {
+		return updatableFields, nil
+	}
+	if len(mask.Paths) == 1 && mask.Paths[0] == "*" {
+		return updatableFields, nil
 	}
 
-	return &api.GetAllUserResponse{Users: users}, nil
+	requested := map[string]bool{}
+	for _, path := range mask.Paths {
+		if !contains(updatableFields, path) {
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("update_mask path %q is not an updatable field", path))
+		}
+		requested[path] = true
+	}
+
+	fields := make([]string, 0, len(requested))
+	for _, field := range updatableFields {
+		if requested[field] {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
@@ -7,19 +7,30 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/auth"
 	"github.com/smockoro/grpc-microservice-sample/pkg/lib"
 	srv "github.com/smockoro/grpc-microservice-sample/pkg/service/user"
+	"github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
 	mock "github.com/smockoro/grpc-microservice-sample/testdata/mock/repository"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// adminCtx : context of a caller allowed to act on every user
+var adminCtx = auth.NewContext(context.Background(), &auth.Claims{Subject: "1", Roles: []string{auth.AdminRole}})
+
 func TestNewUserServiceServer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
 
 	if reflect.TypeOf(s).String() != "*user.server" {
 		t.Errorf("want %s but actual %s", "*user.server", reflect.TypeOf(s))
@@ -31,8 +42,9 @@ func TestCreate(t *testing.T) {
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
 
 	users := map[string]*api.User{
 		"no lost data": &api.User{
@@ -100,7 +112,7 @@ func TestCreate(t *testing.T) {
 		},
 	}
 
-	ctx := context.Background()
+	ctx := adminCtx
 
 	for _, c := range cases {
 		c := c // cascading
@@ -125,8 +137,9 @@ func TestGet(t *testing.T) {
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
 
 	cases := []struct {
 		name string
@@ -134,7 +147,7 @@ func TestGet(t *testing.T) {
 	}{
 		{name: "Get OK", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			reqID := 1
 			user := &api.User{Name: "Bob", Age: 16, Mail: "sample@sample.com", Address: "Tokyo"}
 			req := &api.GetUserRequest{Id: int64(reqID)}
@@ -146,7 +159,7 @@ func TestGet(t *testing.T) {
 		}},
 		{name: "Get NG", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			req := &api.GetUserRequest{}
 			repo.EXPECT().SelectByID(ctx, int64(0)).Return(nil, fmt.Errorf("Error"))
 			_, err := s.Get(ctx, req)
@@ -154,6 +167,17 @@ func TestGet(t *testing.T) {
 				t.Errorf("want %s actual %s", err, "nil")
 			}
 		}},
+		{name: "Get NotFound", f: func(t *testing.T) {
+			t.Parallel()
+			ctx := adminCtx
+			req := &api.GetUserRequest{Id: 9}
+			notFound := status.Error(codes.NotFound, "ID='9' is not found")
+			repo.EXPECT().SelectByID(ctx, int64(9)).Return(nil, notFound)
+			_, err := s.Get(ctx, req)
+			if st := status.Convert(err); st.Code() != codes.NotFound || st.Message() != "ID='9' is not found" {
+				t.Errorf("want %s actual %s", notFound, err)
+			}
+		}},
 	}
 
 	for _, c := range cases {
@@ -167,8 +191,11 @@ func TestUpdate(t *testing.T) {
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
+
+	allFields := []string{repository.FieldName, repository.FieldAge, repository.FieldMail, repository.FieldAddress}
 
 	cases := []struct {
 		name string
@@ -176,7 +203,7 @@ func TestUpdate(t *testing.T) {
 	}{
 		{name: "Update OK", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			user := &api.User{
 				Id:      1,
 				Name:    "Bob",
@@ -185,18 +212,54 @@ func TestUpdate(t *testing.T) {
 				Address: "Tokyo",
 			}
 			req := &api.UpdateUserRequest{User: user}
-			repo.EXPECT().Update(ctx, user).Return(int64(1), nil)
+			repo.EXPECT().Update(ctx, user, allFields).Return(int64(1), nil)
 			_, err := s.Update(ctx, req)
 			if err != nil {
 				t.Errorf("want %s actual %s", "nil", err)
 			}
 		}},
+		{name: "Update with mask", f: func(t *testing.T) {
+			t.Parallel()
+			ctx := adminCtx
+			user := &api.User{Id: 2, Address: "Osaka"}
+			req := &api.UpdateUserRequest{User: user,
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"address", "name", "address"}}}
+			fields := []string{repository.FieldName, repository.FieldAddress}
+			repo.EXPECT().Update(ctx, user, fields).Return(int64(1), nil)
+			_, err := s.Update(ctx, req)
+			if err != nil {
+				t.Errorf("want %s actual %s", "nil", err)
+			}
+		}},
+		{name: "Update with wildcard mask", f: func(t *testing.T) {
+			t.Parallel()
+			ctx := adminCtx
+			user := &api.User{Id: 3}
+			req := &api.UpdateUserRequest{User: user, UpdateMask: &field_mask.FieldMask{Paths: []string{"*"}}}
+			repo.EXPECT().Update(ctx, user, allFields).Return(int64(1), nil)
+			_, err := s.Update(ctx, req)
+			if err != nil {
+				t.Errorf("want %s actual %s", "nil", err)
+			}
+		}},
+		{name: "Update with invalid mask", f: func(t *testing.T) {
+			t.Parallel()
+			ctx := adminCtx
+			for _, paths := range [][]string{{"id"}, {"nickname"}, {"name", "*"}, {"user.name"}} {
+				req := &api.UpdateUserRequest{User: &api.User{Id: 4},
+					UpdateMask: &field_mask.FieldMask{Paths: paths}}
+				_, err := s.Update(ctx, req)
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("paths %v: want code %v but actual %v", paths, codes.InvalidArgument, err)
+				}
+			}
+		}},
 		{name: "Update NG", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			user := &api.User{}
 			req := &api.UpdateUserRequest{User: user}
-			repo.EXPECT().Update(ctx, user).Return(int64(0), fmt.Errorf("Error"))
+			repo.EXPECT().Update(ctx, user, allFields).Return(int64(0), fmt.Errorf("Error"))
 			_, err := s.Update(ctx, req)
 			if err == nil {
 				t.Errorf("want %s actual %s", err, "nil")
@@ -215,8 +278,9 @@ func TestDelete(t *testing.T) {
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
 
 	cases := []struct {
 		name string
@@ -224,10 +288,20 @@ func TestDelete(t *testing.T) {
 	}{
 		{name: "Delete OK", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			reqID := 1
 			req := &api.DeleteUserRequest{Id: int64(reqID)}
-			repo.EXPECT().Delete(ctx, int64(reqID)).Return(int64(1), nil)
+			repo.EXPECT().Delete(ctx, int64(reqID), int64(0)).Return(int64(1), nil)
+			_, err := s.Delete(ctx, req)
+			if err != nil {
+				t.Errorf("want %s actual %s", "nil", err)
+			}
+		}},
+		{name: "Delete with version", f: func(t *testing.T) {
+			t.Parallel()
+			ctx := adminCtx
+			req := &api.DeleteUserRequest{Id: 2, Version: 3}
+			repo.EXPECT().Delete(ctx, int64(2), int64(3)).Return(int64(1), nil)
 			_, err := s.Delete(ctx, req)
 			if err != nil {
 				t.Errorf("want %s actual %s", "nil", err)
@@ -235,10 +309,10 @@ func TestDelete(t *testing.T) {
 		}},
 		{name: "Delete NG", f: func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
+			ctx := adminCtx
 			reqID := 0
 			req := &api.DeleteUserRequest{Id: int64(reqID)}
-			repo.EXPECT().Delete(ctx, int64(reqID)).Return(int64(0), fmt.Errorf("Error"))
+			repo.EXPECT().Delete(ctx, int64(reqID), int64(0)).Return(int64(0), fmt.Errorf("Error"))
 			_, err := s.Delete(ctx, req)
 			if err == nil {
 				t.Errorf("want %s actual %s", err, "nil")
@@ -252,57 +326,354 @@ func TestDelete(t *testing.T) {
 }
 
 func TestGetAll(t *testing.T) {
+	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	users := []*api.User{
+		&api.User{Id: 1, Name: "Bob", Age: 11, Mail: "sample@sample.com", Address: "Tokyo"},
+		&api.User{Id: 2, Name: "Alice", Age: 13, Mail: "example@sample.com", Address: "London"},
+		&api.User{Id: 3, Name: "Carol", Age: 15, Mail: "carol@sample.com", Address: "Osaka"},
+	}
+	// pageToken : next_page_token of the first page of req, which ends at users[1]
+	pageToken := func(req *api.GetAllUserRequest) string {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := mock.NewMockUserRepository(ctrl)
+		repo.EXPECT().SelectAll(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(users, nil)
+		res, err := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer).GetAll(adminCtx, req)
+		if err != nil || res.NextPageToken == "" {
+			t.Fatalf("want next page token but actual %v, %v", res, err)
+		}
+		return res.NextPageToken
+	}
+	nextToken := pageToken(&api.GetAllUserRequest{PageSize: 2})
+	byNameToken := pageToken(&api.GetAllUserRequest{PageSize: 2, OrderBy: "name desc"})
+	after := &api.User{Id: 2, Name: "Alice", Age: 13, Mail: "example@sample.com"}
+	ten, twenty := int64(10), int64(20)
+	byID := repository.Order{Field: repository.OrderByID}
+
+	cases := []struct {
+		name      string
+		req       *api.GetAllUserRequest
+		filter    repository.Filter
+		order     repository.Order
+		page      *repository.Page // nil when the repository must not be called
+		rows      []*api.User
+		repoErr   error
+		wantUsers int
+		wantToken bool
+		wantCode  codes.Code
+	}{
+		{name: "default page size", req: &api.GetAllUserRequest{},
+			order: byID, page: &repository.Page{Limit: 51}, rows: users,
+			wantUsers: 3, wantToken: false, wantCode: codes.OK},
+		{name: "first page", req: &api.GetAllUserRequest{PageSize: 2},
+			order: byID, page: &repository.Page{Limit: 3}, rows: users,
+			wantUsers: 2, wantToken: true, wantCode: codes.OK},
+		{name: "next page", req: &api.GetAllUserRequest{PageSize: 2, PageToken: nextToken},
+			order: byID, page: &repository.Page{After: after, Limit: 3}, rows: users[2:],
+			wantUsers: 1, wantToken: false, wantCode: codes.OK},
+		{name: "page size is capped", req: &api.GetAllUserRequest{PageSize: 5000},
+			order: byID, page: &repository.Page{Limit: 1001}, rows: users,
+			wantUsers: 3, wantToken: false, wantCode: codes.OK},
+		{name: "filter and order", req: &api.GetAllUserRequest{
+			Filter: &api.UserFilter{NamePrefix: "B", MinAge: &wrappers.Int64Value{Value: 10},
+				MaxAge: &wrappers.Int64Value{Value: 20}, MailDomain: "sample.com"},
+			OrderBy: "age DESC"},
+			filter: repository.Filter{NamePrefix: "B", MinAge: &ten, MaxAge: &twenty, MailDomain: "sample.com"},
+			order:  repository.Order{Field: repository.OrderByAge, Desc: true},
+			page:   &repository.Page{Limit: 51}, rows: users[:1],
+			wantUsers: 1, wantToken: false, wantCode: codes.OK},
+		{name: "next page of sorted listing",
+			req:   &api.GetAllUserRequest{PageSize: 2, PageToken: byNameToken, OrderBy: " name  desc "},
+			order: repository.Order{Field: repository.OrderByName, Desc: true},
+			page:  &repository.Page{After: after, Limit: 3}, rows: users[2:],
+			wantUsers: 1, wantToken: false, wantCode: codes.OK},
+		{name: "page token of another order", req: &api.GetAllUserRequest{PageToken: nextToken, OrderBy: "name"},
+			wantCode: codes.InvalidArgument},
+		{name: "page token of another filter", req: &api.GetAllUserRequest{PageToken: nextToken,
+			Filter: &api.UserFilter{NamePrefix: "B"}}, wantCode: codes.InvalidArgument},
+		{name: "unknown order field", req: &api.GetAllUserRequest{OrderBy: "address"},
+			wantCode: codes.InvalidArgument},
+		{name: "unknown order direction", req: &api.GetAllUserRequest{OrderBy: "name up"},
+			wantCode: codes.InvalidArgument},
+		{name: "malformed order", req: &api.GetAllUserRequest{OrderBy: "name desc, id"},
+			wantCode: codes.InvalidArgument},
+		{name: "inverted age range", req: &api.GetAllUserRequest{Filter: &api.UserFilter{
+			MinAge: &wrappers.Int64Value{Value: 20}, MaxAge: &wrappers.Int64Value{Value: 10}}},
+			wantCode: codes.InvalidArgument},
+		{name: "negative page size", req: &api.GetAllUserRequest{PageSize: -1},
+			wantCode: codes.InvalidArgument},
+		{name: "tampered page token", req: &api.GetAllUserRequest{PageToken: nextToken + "x"},
+			wantCode: codes.InvalidArgument},
+		{name: "repository error", req: &api.GetAllUserRequest{},
+			order: byID, page: &repository.Page{Limit: 51}, repoErr: fmt.Errorf("Error"),
+			wantCode: codes.Unknown},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockUserRepository(ctrl)
+			s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
+			ctx := adminCtx
+			if c.page != nil {
+				repo.EXPECT().SelectAll(ctx, c.filter, c.order, *c.page).Return(c.rows, c.repoErr)
+			}
+
+			res, err := s.GetAll(ctx, c.req)
+			if status.Code(err) != c.wantCode {
+				t.Fatalf("want code %v but actual %v", c.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(res.Users) != c.wantUsers {
+				t.Errorf("want %d users but actual %d", c.wantUsers, len(res.Users))
+			}
+			if (res.NextPageToken != "") != c.wantToken {
+				t.Errorf("want next page token: %v but actual %q", c.wantToken, res.NextPageToken)
+			}
+		})
+	}
+}
+
+type listUsersStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*api.User
+	err  error
+}
+
+func (s *listUsersStream) Context() context.Context { return s.ctx }
+
+func (s *listUsersStream) Send(user *api.User) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, user)
+	return nil
+}
+
+func TestListUsers(t *testing.T) {
+	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	users := []*api.User{
+		&api.User{Id: 1, Name: "Bob", Age: 11, Mail: "sample@sample.com", Address: "Tokyo"},
+		&api.User{Id: 2, Name: "Alice", Age: 13, Mail: "example@sample.com", Address: "London"},
+	}
+
+	byID := repository.Order{Field: repository.OrderByID}
+
+	cases := []struct {
+		name       string
+		req        *api.ListUsersRequest
+		filter     repository.Filter
+		order      *repository.Order // nil when the repository must not be called
+		sendErr    error
+		wantSent   int
+		errorIsNil bool
+	}{
+		{name: "stream every user", req: &api.ListUsersRequest{}, order: &byID,
+			sendErr: nil, wantSent: 2, errorIsNil: true},
+		{name: "filter and order", req: &api.ListUsersRequest{
+			Filter: &api.UserFilter{MailDomain: "sample.com"}, OrderBy: "mail desc"},
+			filter:  repository.Filter{MailDomain: "sample.com"},
+			order:   &repository.Order{Field: repository.OrderByMail, Desc: true},
+			sendErr: nil, wantSent: 2, errorIsNil: true},
+		{name: "unknown order field", req: &api.ListUsersRequest{OrderBy: "address"},
+			sendErr: nil, wantSent: 0, errorIsNil: false},
+		{name: "client gone", req: &api.ListUsersRequest{}, order: &byID,
+			sendErr: fmt.Errorf("transport is closing"), wantSent: 0, errorIsNil: false},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockUserRepository(ctrl)
+			s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
+			stream := &listUsersStream{ctx: adminCtx, err: c.sendErr}
+			if c.order != nil {
+				repo.EXPECT().SelectEach(stream.ctx, c.filter, *c.order, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ repository.Filter, _ repository.Order,
+						fn func(*api.User) error) error {
+						for _, user := range users {
+							if err := fn(user); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}
+
+			err := s.ListUsers(c.req, stream)
+			if (err == nil) != c.errorIsNil {
+				t.Fatalf("want error is nil: %v but err is %v", c.errorIsNil, err)
+			}
+			if len(stream.sent) != c.wantSent {
+				t.Errorf("want %d users sent but actual %d", c.wantSent, len(stream.sent))
+			}
+		})
+	}
+}
+
+func TestBatchCreateUsers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
 	repo := mock.NewMockUserRepository(ctrl)
-	s := srv.NewUserServiceServer(repo, stackTracer)
+	s := srv.NewUserServiceServer(repo, stackTracer, pageTokenizer)
+
+	bob := &api.User{Name: "Bob", Age: 16, Mail: "sample@sample.com", Address: "Tokyo"}
+	alice := &api.User{Name: "Alice", Age: 17, Mail: "alice@sample.com", Address: "Osaka"}
 
 	cases := []struct {
-		name string
-		f    func(t *testing.T)
+		name       string
+		users      []*api.User
+		ids        []int64 // nil when the repository must not be called
+		errorIsNil bool
 	}{
-		{name: "GetAll OK", f: func(t *testing.T) {
+		{name: "two users", users: []*api.User{bob, alice}, ids: []int64{1, 2}, errorIsNil: true},
+		{name: "no user", users: []*api.User{}, errorIsNil: false},
+		{name: "unset user", users: []*api.User{bob, nil}, errorIsNil: false},
+	}
+
+	ctx := adminCtx
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
 			t.Parallel()
-			ctx := context.Background()
-			users := []*api.User{
-				&api.User{
-					Id:      1,
-					Name:    "Bob",
-					Age:     11,
-					Mail:    "sample@sample.com",
-					Address: "Tokyo",
-				},
-				&api.User{
-					Id:      2,
-					Name:    "Alice",
-					Age:     13,
-					Mail:    "example@sample.com",
-					Address: "London",
-				},
+			if c.ids != nil {
+				repo.EXPECT().InsertBatch(ctx, c.users).Return(c.ids, nil)
 			}
-			req := &api.GetAllUserRequest{}
-			repo.EXPECT().SelectAll(ctx).Return(users, nil)
-			_, err := s.GetAll(ctx, req)
-			if err != nil {
-				t.Errorf("want %s actual %s", "nil", err)
+			res, err := s.BatchCreateUsers(ctx, &api.BatchCreateUsersRequest{Users: c.users})
+			if (err == nil) != c.errorIsNil {
+				t.Errorf("want error is nil %t actual %v", c.errorIsNil, err)
 			}
-		}},
-		{name: "GetAll NG", f: func(t *testing.T) {
-			t.Parallel()
-			ctx := context.Background()
-			req := &api.GetAllUserRequest{}
-			repo.EXPECT().SelectAll(ctx).Return(nil, fmt.Errorf("Error"))
-			_, err := s.GetAll(ctx, req)
-			if err == nil {
-				t.Errorf("want %s actual %s", err, "nil")
+			if err == nil && len(res.Ids) != len(c.ids) {
+				t.Errorf("want %v actual %v", c.ids, res.Ids)
 			}
-		}},
+		})
 	}
+}
 
-	for _, c := range cases {
-		t.Run(c.name, c.f)
+func TestOwnership(t *testing.T) {
+	stackTracer := lib.NewStackTracer()
+	pageTokenizer, _ := lib.NewPageTokenizer([]byte("secret"))
+	bob := &api.User{Id: 7, Name: "Bob", Age: 16, Mail: "sample@sample.com", Address: "Tokyo"}
+	bobCtx := auth.NewContext(context.Background(), &auth.Claims{Subject: "7", Roles: []string{"user"}})
+	strangerCtx := auth.NewContext(context.Background(), &auth.Claims{Subject: "client-app"})
+	peerCtx := auth.NewContext(context.Background(), &auth.Claims{Peer: &auth.Identity{CommonName: "7"}})
+	id := int64(7)
+	none := int64(0)
+
+	cases := []struct {
+		name   string
+		ctx    context.Context
+		expect func(repo *mock.MockUserRepository)
+		call   func(s api.UserServiceServer, ctx context.Context) error
+		code   codes.Code
+	}{
+		{name: "get own user", ctx: bobCtx,
+			expect: func(repo *mock.MockUserRepository) { repo.EXPECT().SelectByID(bobCtx, id).Return(bob, nil) },
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Get(ctx, &api.GetUserRequest{Id: 7})
+				return err
+			}, code: codes.OK},
+		{name: "get other user", ctx: bobCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Get(ctx, &api.GetUserRequest{Id: 8})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "update other user", ctx: bobCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Update(ctx, &api.UpdateUserRequest{User: &api.User{Id: 8, Name: "Eve"}})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "delete other user", ctx: bobCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Delete(ctx, &api.DeleteUserRequest{Id: 8})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "batch get with other user", ctx: bobCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.BatchGetUsers(ctx, &api.BatchGetUsersRequest{Ids: []int64{7, 8}})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "batch delete with other user", ctx: bobCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.BatchDeleteUsers(ctx, &api.BatchDeleteUsersRequest{
+					Users: []*api.DeleteUserRequest{{Id: 7}, {Id: 8}}})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "get all lists own user", ctx: bobCtx,
+			expect: func(repo *mock.MockUserRepository) {
+				repo.EXPECT().SelectAll(bobCtx, repository.Filter{ID: &id},
+					repository.Order{Field: repository.OrderByID}, repository.Page{Limit: 51}).
+					Return([]*api.User{bob}, nil)
+			},
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.GetAll(ctx, &api.GetAllUserRequest{})
+				return err
+			}, code: codes.OK},
+		{name: "subject which is not an id owns nothing", ctx: strangerCtx,
+			expect: func(repo *mock.MockUserRepository) {
+				repo.EXPECT().SelectAll(strangerCtx, repository.Filter{ID: &none},
+					repository.Order{Field: repository.OrderByID}, repository.Page{Limit: 51}).
+					Return([]*api.User{}, nil)
+			},
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.GetAll(ctx, &api.GetAllUserRequest{})
+				return err
+			}, code: codes.OK},
+		{name: "certificate named as a user id owns nothing", ctx: peerCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Get(ctx, &api.GetUserRequest{Id: 7})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "certificate lists no user", ctx: peerCtx,
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.GetAll(ctx, &api.GetAllUserRequest{})
+				return err
+			}, code: codes.PermissionDenied},
+		{name: "admin gets other user", ctx: adminCtx,
+			expect: func(repo *mock.MockUserRepository) { repo.EXPECT().SelectByID(adminCtx, id).Return(bob, nil) },
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Get(ctx, &api.GetUserRequest{Id: 7})
+				return err
+			}, code: codes.OK},
+		{name: "no verified token", ctx: context.Background(),
+			call: func(s api.UserServiceServer, ctx context.Context) error {
+				_, err := s.Get(ctx, &api.GetUserRequest{Id: 7})
+				return err
+			}, code: codes.Unauthenticated},
 	}
 
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockUserRepository(ctrl)
+			if c.expect != nil {
+				c.expect(repo)
+			}
+			err := c.call(srv.NewUserServiceServer(repo, stackTracer, pageTokenizer), c.ctx)
+			if status.Code(err) != c.code {
+				t.Errorf("want %v but actual %v", c.code, err)
+			}
+		})
+	}
 }
@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Column sizes of the databases under docker/
+const (
+	maxNameLength        = 200
+	maxMailLength        = 200
+	maxAddressLength     = 1024
+	maxDescriptionLength = 1024
+	maxDateLength        = 64
+	maxAge               = 150
+)
+
+// request : collect the violations of the messages req carries
+func (v *violations) request(req interface{}) {
+	switch r := req.(type) {
+	case *api.CreateUserRequest:
+		v.user("user", r.User, nil)
+	case *api.UpdateUserRequest:
+		if r.User != nil {
+			v.id("user.id", r.User.Id)
+		}
+		v.user("user", r.User, r.UpdateMask)
+	case *api.BatchCreateUsersRequest:
+		for i, user := range r.Users {
+			v.user(fmt.Sprintf("users[%d]", i), user, nil)
+		}
+	case *api.CreateItemRequest:
+		v.item("item", r.Item, nil)
+	case *api.UpdateItemRequest:
+		if r.Item != nil {
+			v.id("item.id", r.Item.Id)
+		}
+		v.item("item", r.Item, r.UpdateMask)
+	case *api.BatchCreateItemsRequest:
+		for i, item := range r.Items {
+			v.item(fmt.Sprintf("items[%d]", i), item, nil)
+		}
+	case *api.CreateStoreRequest:
+		v.store("store", r.Store)
+	case *api.UpdateStoreRequest:
+		if r.Store != nil {
+			v.id("store.id", r.Store.Id)
+		}
+		v.store("store", r.Store)
+	case *api.CreateAccountRequest:
+		v.account("account", r.Account)
+	case *api.UpdateAccountRequest:
+		if r.Account != nil {
+			v.id("account.account_id", r.Account.AccountId)
+		}
+		v.account("account", r.Account)
+	}
+}
+
+// user : rules of User. Only the fields in mask are checked, every field
+// when mask is unset or "*".
+func (v *violations) user(path string, user *api.User, mask *field_mask.FieldMask) {
+	if user == nil {
+		v.add(path, "is required")
+		return
+	}
+	if masked(mask, "name") {
+		v.name(path+".name", user.Name)
+	}
+	if masked(mask, "age") && (user.Age < 0 || user.Age > maxAge) {
+		v.add(path+".age", fmt.Sprintf("must be between 0 and %d", maxAge))
+	}
+	if masked(mask, "mail") {
+		v.mail(path+".mail", user.Mail)
+	}
+	if masked(mask, "address") {
+		v.length(path+".address", user.Address, maxAddressLength)
+	}
+}
+
+// item : rules of Item, checked like user
+func (v *violations) item(path string, item *api.Item, mask *field_mask.FieldMask) {
+	if item == nil {
+		v.add(path, "is required")
+		return
+	}
+	if masked(mask, "name") {
+		v.name(path+".name", item.Name)
+	}
+	if masked(mask, "description") {
+		v.length(path+".description", item.Description, maxDescriptionLength)
+	}
+	if masked(mask, "price") && item.Price < 0 {
+		v.add(path+".price", "must not be negative")
+	}
+}
+
+func (v *violations) store(path string, store *api.Store) {
+	if store == nil {
+		v.add(path, "is required")
+		return
+	}
+	v.name(path+".name", store.Name)
+	v.mail(path+".mail", store.Mail)
+	v.length(path+".address", store.Address, maxAddressLength)
+}
+
+func (v *violations) account(path string, account *api.Account) {
+	if account == nil {
+		v.add(path, "is required")
+		return
+	}
+	if strings.TrimSpace(account.Date) == "" {
+		v.add(path+".date", "is required")
+	}
+	v.length(path+".date", account.Date, maxDateLength)
+	if account.StoreId <= 0 {
+		v.add(path+".store_id", "must be a positive id")
+	}
+	for i, detail := range account.Details {
+		if detail.GetItemId() <= 0 {
+			v.add(fmt.Sprintf("%s.details[%d].item_id", path, i), "must be a positive id")
+		}
+	}
+}
+
+func (v *violations) id(path string, id int64) {
+	if id <= 0 {
+		v.add(path, "must be a positive id")
+	}
+}
+
+func (v *violations) name(path, name string) {
+	if strings.TrimSpace(name) == "" {
+		v.add(path, "is required")
+		return
+	}
+	v.length(path, name, maxNameLength)
+}
+
+// mail : a bare address such as bob@sample.com, without a display name
+func (v *violations) mail(path, address string) {
+	if address == "" {
+		v.add(path, "is required")
+		return
+	}
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address || parsed.Name != "" {
+		v.add(path, "is not a valid mail address")
+		return
+	}
+	v.length(path, address, maxMailLength)
+}
+
+func (v *violations) length(path, s string, max int) {
+	if utf8.RuneCountInString(s) > max {
+		v.add(path, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
+
+// masked : whether field is written by an update with mask
+func masked(mask *field_mask.FieldMask, field string) bool {
+	paths := mask.GetPaths()
+	if len(paths) == 0 || (len(paths) == 1 && paths[0] == "*") {
+		return true
+	}
+	for _, path := range paths {
+		if path == field {
+			return true
+		}
+	}
+	return false
+}
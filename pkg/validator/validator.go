@@ -0,0 +1,63 @@
+// Package validator checks request messages before they reach a service.
+// Every invalid field is reported as a google.rpc.BadRequest field
+// violation, so clients can show an error next to each field.
+package validator
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor : fail requests with an invalid field with
+// codes.InvalidArgument before handler is called
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := Validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Validate : nil when req is valid or has no rules. Otherwise a status of
+// codes.InvalidArgument carrying a BadRequest with every violation.
+func Validate(req interface{}) error {
+	v := &violations{}
+	v.request(req)
+	return v.err()
+}
+
+type violations struct {
+	list []*errdetails.BadRequest_FieldViolation
+}
+
+func (v *violations) add(field, description string) {
+	v.list = append(v.list, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+}
+
+func (v *violations) err() error {
+	if len(v.list) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(v.list))
+	for i, fv := range v.list {
+		msgs[i] = fv.Field + " " + fv.Description
+	}
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(msgs, ", "))
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v.list})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,125 @@
+package validator_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidate(t *testing.T) {
+	bob := &api.User{Name: "Bob", Age: 11, Mail: "bob@sample.com", Address: "Tokyo"}
+	pen := &api.Item{Name: "Pen", Description: "black ink", Price: 100}
+
+	cases := []struct {
+		name   string
+		req    interface{}
+		fields []string // fields of the violations, nil when req is valid
+	}{
+		{name: "valid user", req: &api.CreateUserRequest{User: bob}},
+		{name: "unset user", req: &api.CreateUserRequest{}, fields: []string{"user"}},
+		{name: "invalid user",
+			req:    &api.CreateUserRequest{User: &api.User{Name: " ", Age: -1, Mail: "bob", Address: "Tokyo"}},
+			fields: []string{"user.name", "user.age", "user.mail"}},
+		{name: "mail with display name",
+			req:    &api.CreateUserRequest{User: &api.User{Name: "Bob", Mail: "Bob <bob@sample.com>"}},
+			fields: []string{"user.mail"}},
+		{name: "too long name",
+			req:    &api.CreateUserRequest{User: &api.User{Name: strings.Repeat("あ", 201), Mail: "bob@sample.com"}},
+			fields: []string{"user.name"}},
+		{name: "update without id", req: &api.UpdateUserRequest{User: bob}, fields: []string{"user.id"}},
+		{name: "update checks only masked fields",
+			req: &api.UpdateUserRequest{User: &api.User{Id: 1, Age: 20},
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"age"}}}},
+		{name: "update checks masked fields",
+			req: &api.UpdateUserRequest{User: &api.User{Id: 1, Age: 200},
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"age"}}},
+			fields: []string{"user.age"}},
+		{name: "batch of users", req: &api.BatchCreateUsersRequest{Users: []*api.User{bob, {Name: "Alice"}, nil}},
+			fields: []string{"users[1].mail", "users[2]"}},
+		{name: "valid item", req: &api.CreateItemRequest{Item: pen}},
+		{name: "free item", req: &api.CreateItemRequest{Item: &api.Item{Name: "Sample"}}},
+		{name: "negative price", req: &api.CreateItemRequest{Item: &api.Item{Name: "Pen", Price: -1}},
+			fields: []string{"item.price"}},
+		{name: "update item without name",
+			req: &api.UpdateItemRequest{Item: &api.Item{Id: 1, Price: 100},
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "price"}}},
+			fields: []string{"item.name"}},
+		{name: "batch of items", req: &api.BatchCreateItemsRequest{Items: []*api.Item{{Price: -1}}},
+			fields: []string{"items[0].name", "items[0].price"}},
+		{name: "valid store",
+			req: &api.CreateStoreRequest{Store: &api.Store{Name: "Main", Mail: "main@sample.com"}}},
+		{name: "invalid store", req: &api.UpdateStoreRequest{Store: &api.Store{Mail: "main@"}},
+			fields: []string{"store.id", "store.name", "store.mail"}},
+		{name: "valid account", req: &api.CreateAccountRequest{Account: &api.Account{
+			Date: "2019-05-01", StoreId: 1, Details: []*api.Account_Detail{{ItemId: 1}}}}},
+		{name: "invalid account", req: &api.UpdateAccountRequest{Account: &api.Account{
+			Details: []*api.Account_Detail{{ItemId: 1}, {}}}},
+			fields: []string{"account.account_id", "account.date", "account.store_id", "account.details[1].item_id"}},
+		{name: "request without rules", req: &api.GetUserRequest{}},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			err := validator.Validate(c.req)
+			if c.fields == nil {
+				if err != nil {
+					t.Errorf("error was not expected but actual %v", err)
+				}
+				return
+			}
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("want code %v but actual %v", codes.InvalidArgument, err)
+			}
+			if actual := violatedFields(t, err); !reflect.DeepEqual(actual, c.fields) {
+				t.Errorf("want violations of %v but actual %v", c.fields, actual)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := validator.UnaryServerInterceptor()
+	ctx := context.Background()
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &api.CreateItemResponse{Id: 1}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/api.ItemService/Create"}
+
+	_, err := interceptor(ctx, &api.CreateItemRequest{Item: &api.Item{Price: -1}}, info, handler)
+	if status.Code(err) != codes.InvalidArgument || called {
+		t.Errorf("want code %v without calling handler but actual %v, %t", codes.InvalidArgument, err, called)
+	}
+
+	res, err := interceptor(ctx, &api.CreateItemRequest{Item: &api.Item{Name: "Pen"}}, info, handler)
+	if err != nil || !called || res.(*api.CreateItemResponse).Id != 1 {
+		t.Errorf("want response of handler but actual %v, %v", res, err)
+	}
+}
+
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			fields := []string{}
+			for _, fv := range br.FieldViolations {
+				fields = append(fields, fv.Field)
+			}
+			return fields
+		}
+	}
+	t.Fatalf("want a BadRequest detail but actual %v", err)
+	return nil
+}
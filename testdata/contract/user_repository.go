@@ -0,0 +1,649 @@
+// Package contract holds behaviour every repository backend has to share.
+// Each backend runs the suite from its own _test.go file, so a new
+// implementation cannot drift from the others unnoticed.
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smockoro/grpc-microservice-sample/pkg/api"
+	"github.com/smockoro/grpc-microservice-sample/pkg/domain"
+	repo "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
+	"golang.org/x/xerrors"
+)
+
+// allFields : every field Update can write
+var allFields = []string{repo.FieldName, repo.FieldAge, repo.FieldMail, repo.FieldAddress}
+
+// allUsers : a page large enough for every case of the suite
+var allUsers = repo.Page{Limit: 100}
+
+// UserRepositoryFactory : build an empty UserRepository for one test case
+type UserRepositoryFactory func(t *testing.T) repo.UserRepository
+
+// TestUserRepository : run every UserRepository contract case on fresh
+// repositories built by newRepo.
+func TestUserRepository(t *testing.T, newRepo UserRepositoryFactory) {
+	cases := []struct {
+		name string
+		f    func(t *testing.T, r repo.UserRepository)
+	}{
+		{name: "InsertIssuesIncreasingIDs", f: InsertIssuesIncreasingIDs},
+		{name: "SelectByIDReturnsInserted", f: SelectByIDReturnsInserted},
+		{name: "SelectAllOrderedByID", f: SelectAllOrderedByID},
+		{name: "SelectAllPagesByID", f: SelectAllPagesByID},
+		{name: "SelectAllFilters", f: SelectAllFilters},
+		{name: "SelectAllSortsAndPages", f: SelectAllSortsAndPages},
+		{name: "SelectAllRejectsUnknownOrder", f: SelectAllRejectsUnknownOrder},
+		{name: "SelectEachVisitsUsersInIDOrder", f: SelectEachVisitsUsersInIDOrder},
+		{name: "SelectEachFiltersAndSorts", f: SelectEachFiltersAndSorts},
+		{name: "SelectEachStopsOnError", f: SelectEachStopsOnError},
+		{name: "UpdateOverwritesUser", f: UpdateOverwritesUser},
+		{name: "UpdateWritesOnlyGivenFields", f: UpdateWritesOnlyGivenFields},
+		{name: "UpdateRejectsUnknownField", f: UpdateRejectsUnknownField},
+		{name: "WritesIncrementVersion", f: WritesIncrementVersion},
+		{name: "StaleVersionIsAborted", f: StaleVersionIsAborted},
+		{name: "DeleteRemovesUser", f: DeleteRemovesUser},
+		{name: "IDsAreNotReused", f: IDsAreNotReused},
+		{name: "InsertBatchReturnsIDsInOrder", f: InsertBatchReturnsIDsInOrder},
+		{name: "SelectByIDsSkipsMissing", f: SelectByIDsSkipsMissing},
+		{name: "DeleteBatchIsAllOrNothing", f: DeleteBatchIsAllOrNothing},
+		{name: "MissingUserIsNotFound", f: MissingUserIsNotFound},
+	}
+
+	for _, c := range cases {
+		c := c // cascading
+		t.Run(c.name, func(t *testing.T) {
+			c.f(t, newRepo(t))
+		})
+	}
+}
+
+func InsertIssuesIncreasingIDs(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	var last int64
+	for _, user := range testUsers() {
+		id, err := r.Insert(ctx, user)
+		if err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+		if id <= last {
+			t.Errorf("want id greater than %d but actual %d", last, id)
+		}
+		last = id
+	}
+}
+
+func SelectByIDReturnsInserted(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	for _, user := range testUsers() {
+		id, err := r.Insert(ctx, user)
+		if err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+		user.Id = id
+
+		actual, err := r.SelectByID(ctx, id)
+		if err != nil {
+			t.Fatalf("error was not expected while Select by ID stats: %s", err)
+		}
+		assertUser(t, user, actual)
+	}
+}
+
+func SelectAllOrderedByID(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	list, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if list == nil || len(list) != 0 {
+		t.Fatalf("want empty list but actual %v", list)
+	}
+
+	users := testUsers()
+	for _, user := range users {
+		if user.Id, err = r.Insert(ctx, user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+
+	list, err = r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != len(users) {
+		t.Fatalf("want %d users but actual %d", len(users), len(list))
+	}
+	for i := range users {
+		assertUser(t, users[i], list[i])
+	}
+}
+
+func SelectAllPagesByID(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	users := testUsers()
+	for _, user := range users {
+		var err error
+		if user.Id, err = r.Insert(ctx, user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+
+	first, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, repo.Page{Limit: 2})
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("want 2 users but actual %d", len(first))
+	}
+	assertUser(t, users[0], first[0])
+	assertUser(t, users[1], first[1])
+
+	second, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, repo.Page{After: first[1], Limit: 2})
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(second) != 1 {
+		t.Fatalf("want 1 user but actual %d", len(second))
+	}
+	assertUser(t, users[2], second[0])
+
+	last, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, repo.Page{After: second[0], Limit: 2})
+	if err != nil || last == nil || len(last) != 0 {
+		t.Errorf("want empty list but actual %v, %v", last, err)
+	}
+}
+
+// SelectAllFilters : only users matching every condition of the filter are
+// listed. Letter case of patterns is left to the backend's collation.
+func SelectAllFilters(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	users := insertUsers(t, r, append(testUsers(),
+		&api.User{Name: "Caroline", Age: 30, Mail: "caroline@example.org", Address: "Kyoto"}))
+	age := func(v int64) *int64 { return &v }
+
+	cases := []struct {
+		name   string
+		filter repo.Filter
+		want   []*api.User
+	}{
+		{name: "no condition", filter: repo.Filter{}, want: users},
+		{name: "id", filter: repo.Filter{ID: &users[1].Id}, want: users[1:2]},
+		{name: "id and name prefix", filter: repo.Filter{ID: &users[1].Id, NamePrefix: "Car"}, want: []*api.User{}},
+		{name: "name prefix", filter: repo.Filter{NamePrefix: "Car"}, want: users[2:4]},
+		{name: "min age", filter: repo.Filter{MinAge: age(12)}, want: users[1:4]},
+		{name: "max age", filter: repo.Filter{MaxAge: age(12)}, want: users[0:2]},
+		{name: "age range", filter: repo.Filter{MinAge: age(12), MaxAge: age(13)}, want: users[1:3]},
+		{name: "mail domain", filter: repo.Filter{MailDomain: "example.org"}, want: users[3:4]},
+		{name: "all conditions", filter: repo.Filter{NamePrefix: "Car", MaxAge: age(20), MailDomain: "sample.com"},
+			want: users[2:3]},
+		{name: "wildcard is literal", filter: repo.Filter{NamePrefix: "%"}, want: []*api.User{}},
+		{name: "underscore is literal", filter: repo.Filter{NamePrefix: "_ob"}, want: []*api.User{}},
+		{name: "domain is not a suffix match", filter: repo.Filter{MailDomain: "ample.com"}, want: []*api.User{}},
+	}
+
+	for _, c := range cases {
+		list, err := r.SelectAll(ctx, c.filter, repo.Order{}, allUsers)
+		if err != nil {
+			t.Fatalf("%s: error was not expected while Select All stats: %s", c.name, err)
+		}
+		assertUsers(t, c.name, c.want, list)
+	}
+}
+
+// SelectAllSortsAndPages : pages of a sorted listing continue after the
+// previous page even when the sort key has ties.
+func SelectAllSortsAndPages(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	users := insertUsers(t, r, append(testUsers(),
+		&api.User{Name: "Bob", Age: 40, Mail: "bob2@sample.com", Address: "Sapporo"}))
+	bob, alice, carol, bob2 := users[0], users[1], users[2], users[3]
+
+	cases := []struct {
+		name  string
+		order repo.Order
+		want  []*api.User
+	}{
+		{name: "name", order: repo.Order{Field: repo.OrderByName}, want: []*api.User{alice, bob, bob2, carol}},
+		{name: "name desc", order: repo.Order{Field: repo.OrderByName, Desc: true},
+			want: []*api.User{carol, bob, bob2, alice}},
+		{name: "age desc", order: repo.Order{Field: repo.OrderByAge, Desc: true},
+			want: []*api.User{bob2, carol, alice, bob}},
+		{name: "mail", order: repo.Order{Field: repo.OrderByMail}, want: []*api.User{alice, bob2, bob, carol}},
+		{name: "id desc", order: repo.Order{Field: repo.OrderByID, Desc: true},
+			want: []*api.User{bob2, carol, alice, bob}},
+	}
+
+	for _, c := range cases {
+		list := []*api.User{}
+		page := repo.Page{Limit: 2}
+		for {
+			got, err := r.SelectAll(ctx, repo.Filter{}, c.order, page)
+			if err != nil {
+				t.Fatalf("%s: error was not expected while Select All stats: %s", c.name, err)
+			}
+			list = append(list, got...)
+			if len(got) < page.Limit || len(list) > len(users) {
+				break
+			}
+			page.After = got[len(got)-1]
+		}
+		assertUsers(t, c.name, c.want, list)
+	}
+}
+
+func SelectAllRejectsUnknownOrder(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	order := repo.Order{Field: "address; DROP TABLE users"}
+
+	if _, err := r.SelectAll(ctx, repo.Filter{}, order, allUsers); domain.KindOf(err) != domain.Invalid {
+		t.Errorf("SelectAll: want %v but actual %v", domain.Invalid, err)
+	}
+	err := r.SelectEach(ctx, repo.Filter{}, order, func(*api.User) error { return nil })
+	if domain.KindOf(err) != domain.Invalid {
+		t.Errorf("SelectEach: want %v but actual %v", domain.Invalid, err)
+	}
+}
+
+func SelectEachVisitsUsersInIDOrder(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	users := testUsers()
+	for _, user := range users {
+		var err error
+		if user.Id, err = r.Insert(ctx, user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+
+	visited := []*api.User{}
+	err := r.SelectEach(ctx, repo.Filter{}, repo.Order{}, func(user *api.User) error {
+		visited = append(visited, user)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error was not expected while Select Each stats: %s", err)
+	}
+	if len(visited) != len(users) {
+		t.Fatalf("want %d users but actual %d", len(users), len(visited))
+	}
+	for i := range users {
+		assertUser(t, users[i], visited[i])
+	}
+}
+
+func SelectEachFiltersAndSorts(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	users := insertUsers(t, r, testUsers())
+	minAge := int64(12)
+
+	visited := []*api.User{}
+	err := r.SelectEach(ctx, repo.Filter{MinAge: &minAge}, repo.Order{Field: repo.OrderByName},
+		func(user *api.User) error {
+			visited = append(visited, user)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("error was not expected while Select Each stats: %s", err)
+	}
+	assertUsers(t, "min age by name", []*api.User{users[1], users[2]}, visited)
+}
+
+func SelectEachStopsOnError(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	for _, user := range testUsers() {
+		if _, err := r.Insert(ctx, user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+
+	stop := xerrors.New("client gone")
+	calls := 0
+	err := r.SelectEach(ctx, repo.Filter{}, repo.Order{}, func(*api.User) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("want %v but actual %v", stop, err)
+	}
+	if calls != 1 {
+		t.Errorf("want 1 call but actual %d", calls)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = r.SelectEach(canceled, repo.Filter{}, repo.Order{}, func(*api.User) error { return nil })
+	if !xerrors.Is(err, context.Canceled) {
+		t.Errorf("want %v but actual %v", context.Canceled, err)
+	}
+}
+
+func UpdateOverwritesUser(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	user := testUsers()[0]
+	id, err := r.Insert(ctx, user)
+	if err != nil {
+		t.Fatalf("error was not expected while Insert stats: %s", err)
+	}
+
+	updated := &api.User{Id: id, Name: "Alice", Age: 20, Mail: "alice@sample.com", Address: "Osaka"}
+	rows, err := r.Update(ctx, updated, allFields)
+	if err != nil {
+		t.Fatalf("error was not expected while Update stats: %s", err)
+	}
+	if rows != 1 {
+		t.Errorf("want 1 row updated but actual %d", rows)
+	}
+
+	actual, err := r.SelectByID(ctx, id)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	assertUser(t, updated, actual)
+
+	// writing the same values again still finds the row
+	if rows, err := r.Update(ctx, updated, allFields); err != nil || rows != 1 {
+		t.Errorf("want 1 row updated but actual %d, %v", rows, err)
+	}
+}
+
+func UpdateWritesOnlyGivenFields(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	users := insertUsers(t, r, testUsers())
+
+	cases := []struct {
+		name   string
+		fields []string
+		want   func(u *api.User)
+	}{
+		{name: "address", fields: []string{repo.FieldAddress},
+			want: func(u *api.User) { u.Address = "Fukuoka" }},
+		{name: "age and mail", fields: []string{repo.FieldAge, repo.FieldMail},
+			want: func(u *api.User) { u.Age, u.Mail = 99, "new@sample.com" }},
+		{name: "name to zero value", fields: []string{repo.FieldName},
+			want: func(u *api.User) { u.Name = "" }},
+	}
+
+	for i, c := range cases {
+		id := users[i].Id
+		change := &api.User{Id: id, Name: "", Age: 99, Mail: "new@sample.com", Address: "Fukuoka"}
+		if rows, err := r.Update(ctx, change, c.fields); err != nil || rows != 1 {
+			t.Fatalf("%s: want 1 row updated but actual %d, %v", c.name, rows, err)
+		}
+
+		want := *users[i]
+		c.want(&want)
+		actual, err := r.SelectByID(ctx, id)
+		if err != nil {
+			t.Fatalf("%s: error was not expected while Select by ID stats: %s", c.name, err)
+		}
+		assertUser(t, &want, actual)
+	}
+}
+
+func UpdateRejectsUnknownField(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	user := insertUsers(t, r, testUsers()[:1])[0]
+
+	for _, fields := range [][]string{{"id"}, {repo.FieldName, "address; DROP TABLE users"}, {}} {
+		changed := &api.User{Id: user.Id, Name: "Mallory"}
+		if _, err := r.Update(ctx, changed, fields); domain.KindOf(err) != domain.Invalid {
+			t.Errorf("fields %v: want %v but actual %v", fields, domain.Invalid, err)
+		}
+	}
+
+	actual, err := r.SelectByID(ctx, user.Id)
+	if err != nil {
+		t.Fatalf("error was not expected while Select by ID stats: %s", err)
+	}
+	assertUser(t, user, actual)
+}
+
+func WritesIncrementVersion(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	user := insertUsers(t, r, testUsers()[:1])[0]
+
+	for want := int64(1); want <= 3; want++ {
+		actual, err := r.SelectByID(ctx, user.Id)
+		if err != nil {
+			t.Fatalf("error was not expected while Select by ID stats: %s", err)
+		}
+		if actual.Version != want {
+			t.Fatalf("want version %d but actual %d", want, actual.Version)
+		}
+
+		change := &api.User{Id: user.Id, Age: 20 + want, Version: actual.Version}
+		if _, err := r.Update(ctx, change, []string{repo.FieldAge}); err != nil {
+			t.Fatalf("error was not expected while Update stats: %s", err)
+		}
+	}
+
+	list, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil || len(list) != 1 || list[0].Version != 4 {
+		t.Errorf("want version 4 listed but actual %v, %v", list, err)
+	}
+}
+
+func StaleVersionIsAborted(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	user := insertUsers(t, r, testUsers()[:1])[0]
+
+	// another writer moves the user to version 2
+	if _, err := r.Update(ctx, &api.User{Id: user.Id, Name: "Alice", Version: 1}, []string{repo.FieldName}); err != nil {
+		t.Fatalf("error was not expected while Update stats: %s", err)
+	}
+
+	rows, err := r.Update(ctx, &api.User{Id: user.Id, Name: "Carol", Version: 1}, []string{repo.FieldName})
+	if domain.KindOf(err) != domain.Conflict || rows != -1 {
+		t.Errorf("Update: want %v but actual %d, %v", domain.Conflict, rows, err)
+	}
+	rows, err = r.Delete(ctx, user.Id, 1)
+	if domain.KindOf(err) != domain.Conflict || rows != -1 {
+		t.Errorf("Delete: want %v but actual %d, %v", domain.Conflict, rows, err)
+	}
+	if actual, err := r.SelectByID(ctx, user.Id); err != nil || actual.Name != "Alice" || actual.Version != 2 {
+		t.Errorf("want Alice at version 2 but actual %v, %v", actual, err)
+	}
+
+	if rows, err := r.Delete(ctx, user.Id, 2); err != nil || rows != 1 {
+		t.Errorf("Delete: want 1 row deleted but actual %d, %v", rows, err)
+	}
+	if _, err := r.Delete(ctx, user.Id, 2); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("Delete: want %v but actual %v", domain.NotFound, err)
+	}
+}
+
+func DeleteRemovesUser(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	users := testUsers()
+	for _, user := range users {
+		var err error
+		if user.Id, err = r.Insert(ctx, user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+
+	rows, err := r.Delete(ctx, users[0].Id, 0)
+	if err != nil {
+		t.Fatalf("error was not expected while Delete stats: %s", err)
+	}
+	if rows != 1 {
+		t.Errorf("want 1 row deleted but actual %d", rows)
+	}
+
+	if _, err := r.SelectByID(ctx, users[0].Id); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("want %v but actual %v", domain.NotFound, err)
+	}
+	list, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	if len(list) != len(users)-1 {
+		t.Errorf("want %d users but actual %d", len(users)-1, len(list))
+	}
+}
+
+func IDsAreNotReused(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+
+	id, err := r.Insert(ctx, testUsers()[0])
+	if err != nil {
+		t.Fatalf("error was not expected while Insert stats: %s", err)
+	}
+	if _, err := r.Delete(ctx, id, 0); err != nil {
+		t.Fatalf("error was not expected while Delete stats: %s", err)
+	}
+
+	next, err := r.Insert(ctx, testUsers()[1])
+	if err != nil {
+		t.Fatalf("error was not expected while Insert stats: %s", err)
+	}
+	if next <= id {
+		t.Errorf("want id greater than deleted %d but actual %d", id, next)
+	}
+}
+
+func InsertBatchReturnsIDsInOrder(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	insertUsers(t, r, testUsers()[:1])
+
+	users := testUsers()
+	ids, err := r.InsertBatch(ctx, users)
+	if err != nil {
+		t.Fatalf("error was not expected while Insert Batch stats: %s", err)
+	}
+	if len(ids) != len(users) {
+		t.Fatalf("want %d ids but actual %v", len(users), ids)
+	}
+	for i, user := range users {
+		user.Id = ids[i]
+		actual, err := r.SelectByID(ctx, ids[i])
+		if err != nil {
+			t.Fatalf("error was not expected while Select by ID stats: %s", err)
+		}
+		assertUser(t, user, actual)
+		if actual.Version != 1 {
+			t.Errorf("want version 1 but actual %d", actual.Version)
+		}
+	}
+}
+
+func SelectByIDsSkipsMissing(t *testing.T, r repo.UserRepository) {
+	users := insertUsers(t, r, testUsers())
+
+	list, err := r.SelectByIDs(context.Background(), []int64{users[2].Id, users[2].Id + 1, users[0].Id})
+	if err != nil {
+		t.Fatalf("error was not expected while Select by IDs stats: %s", err)
+	}
+	assertUsers(t, "SelectByIDs", []*api.User{users[0], users[2]}, list)
+}
+
+func DeleteBatchIsAllOrNothing(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	users := insertUsers(t, r, testUsers())
+
+	cases := []struct {
+		name string
+		keys []repo.Key
+		want domain.Kind
+	}{
+		{name: "missing id", keys: []repo.Key{{ID: users[0].Id}, {ID: users[2].Id + 1}}, want: domain.NotFound},
+		{name: "stale version", keys: []repo.Key{{ID: users[0].Id}, {ID: users[1].Id, Version: 2}},
+			want: domain.Conflict},
+	}
+	for _, c := range cases {
+		rows, err := r.DeleteBatch(ctx, c.keys)
+		if domain.KindOf(err) != c.want || rows != -1 {
+			t.Errorf("%s: want %v but actual %d, %v", c.name, c.want, rows, err)
+		}
+	}
+	list, err := r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	assertUsers(t, "after failed DeleteBatch", users, list)
+
+	rows, err := r.DeleteBatch(ctx, []repo.Key{{ID: users[0].Id}, {ID: users[1].Id, Version: 1}})
+	if err != nil || rows != 2 {
+		t.Fatalf("want 2 rows deleted but actual %d, %v", rows, err)
+	}
+	list, err = r.SelectAll(ctx, repo.Filter{}, repo.Order{}, allUsers)
+	if err != nil {
+		t.Fatalf("error was not expected while Select All stats: %s", err)
+	}
+	assertUsers(t, "after DeleteBatch", users[2:], list)
+}
+
+// MissingUserIsNotFound : every lookup by an unknown ID fails with
+// domain.NotFound. It expects r to hold no user with ID 1.
+func MissingUserIsNotFound(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	const missing = int64(1)
+
+	if _, err := r.SelectByID(ctx, missing); domain.KindOf(err) != domain.NotFound {
+		t.Errorf("SelectByID: want %v but actual %v", domain.NotFound, err)
+	}
+
+	rows, err := r.Update(ctx, &api.User{Id: missing, Name: "Bob"}, []string{repo.FieldName})
+	if domain.KindOf(err) != domain.NotFound {
+		t.Errorf("Update: want %v but actual %v", domain.NotFound, err)
+	}
+	if rows != -1 {
+		t.Errorf("Update: want -1 but actual %d", rows)
+	}
+
+	rows, err = r.Delete(ctx, missing, 0)
+	if domain.KindOf(err) != domain.NotFound {
+		t.Errorf("Delete: want %v but actual %v", domain.NotFound, err)
+	}
+	if rows != -1 {
+		t.Errorf("Delete: want -1 but actual %d", rows)
+	}
+}
+
+func testUsers() []*api.User {
+	return []*api.User{
+		{Name: "Bob", Age: 11, Mail: "bob@sample.com", Address: "Tokyo"},
+		{Name: "Alice", Age: 12, Mail: "alice@sample.com", Address: "Osaka"},
+		{Name: "Carol", Age: 13, Mail: "carol@sample.com", Address: "Nagoya"},
+	}
+}
+
+func insertUsers(t *testing.T, r repo.UserRepository, users []*api.User) []*api.User {
+	t.Helper()
+	for _, user := range users {
+		var err error
+		if user.Id, err = r.Insert(context.Background(), user); err != nil {
+			t.Fatalf("error was not expected while Insert stats: %s", err)
+		}
+	}
+	return users
+}
+
+func assertUsers(t *testing.T, name string, want, actual []*api.User) {
+	t.Helper()
+	if len(actual) != len(want) {
+		t.Errorf("%s: want %v but actual %v", name, want, actual)
+		return
+	}
+	for i := range want {
+		assertUser(t, want[i], actual[i])
+	}
+}
+
+func assertUser(t *testing.T, want, actual *api.User) {
+	t.Helper()
+	if actual.Id != want.Id || actual.Name != want.Name || actual.Age != want.Age ||
+		actual.Mail != want.Mail || actual.Address != want.Address {
+		t.Errorf("want %v but actual %v", want, actual)
+	}
+}
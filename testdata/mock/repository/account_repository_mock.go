@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	api "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repository "github.com/smockoro/grpc-microservice-sample/pkg/service/account/repository"
+	reflect "reflect"
+)
+
+// MockAccountRepository is a mock of AccountRepository interface
+type MockAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepositoryMockRecorder
+}
+
+// MockAccountRepositoryMockRecorder is the mock recorder for MockAccountRepository
+type MockAccountRepositoryMockRecorder struct {
+	mock *MockAccountRepository
+}
+
+// NewMockAccountRepository creates a new mock instance
+func NewMockAccountRepository(ctrl *gomock.Controller) *MockAccountRepository {
+	mock := &MockAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockAccountRepository) Insert(arg0 context.Context, arg1 *api.Account) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockAccountRepositoryMockRecorder) Insert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAccountRepository)(nil).Insert), arg0, arg1)
+}
+
+// SelectByID mocks base method
+func (m *MockAccountRepository) SelectByID(arg0 context.Context, arg1 int64) (*api.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByID", arg0, arg1)
+	ret0, _ := ret[0].(*api.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByID indicates an expected call of SelectByID
+func (mr *MockAccountRepositoryMockRecorder) SelectByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockAccountRepository)(nil).SelectByID), arg0, arg1)
+}
+
+// SelectAll mocks base method
+func (m *MockAccountRepository) SelectAll(arg0 context.Context, arg1 repository.Page) ([]*api.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1)
+	ret0, _ := ret[0].([]*api.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAll indicates an expected call of SelectAll
+func (mr *MockAccountRepositoryMockRecorder) SelectAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockAccountRepository)(nil).SelectAll), arg0, arg1)
+}
+
+// Update mocks base method
+func (m *MockAccountRepository) Update(arg0 context.Context, arg1 *api.Account) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockAccountRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAccountRepository)(nil).Update), arg0, arg1)
+}
+
+// Delete mocks base method
+func (m *MockAccountRepository) Delete(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockAccountRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccountRepository)(nil).Delete), arg0, arg1)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	api "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repository "github.com/smockoro/grpc-microservice-sample/pkg/service/book/repository"
+	reflect "reflect"
+)
+
+// MockBookRepository is a mock of BookRepository interface
+type MockBookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookRepositoryMockRecorder
+}
+
+// MockBookRepositoryMockRecorder is the mock recorder for MockBookRepository
+type MockBookRepositoryMockRecorder struct {
+	mock *MockBookRepository
+}
+
+// NewMockBookRepository creates a new mock instance
+func NewMockBookRepository(ctrl *gomock.Controller) *MockBookRepository {
+	mock := &MockBookRepository{ctrl: ctrl}
+	mock.recorder = &MockBookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockBookRepository) Insert(arg0 context.Context, arg1 *api.Book) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockBookRepositoryMockRecorder) Insert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBookRepository)(nil).Insert), arg0, arg1)
+}
+
+// SelectByID mocks base method
+func (m *MockBookRepository) SelectByID(arg0 context.Context, arg1 int64) (*api.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByID", arg0, arg1)
+	ret0, _ := ret[0].(*api.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByID indicates an expected call of SelectByID
+func (mr *MockBookRepositoryMockRecorder) SelectByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockBookRepository)(nil).SelectByID), arg0, arg1)
+}
+
+// SelectAll mocks base method
+func (m *MockBookRepository) SelectAll(arg0 context.Context, arg1 repository.Page) ([]*api.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1)
+	ret0, _ := ret[0].([]*api.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAll indicates an expected call of SelectAll
+func (mr *MockBookRepositoryMockRecorder) SelectAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockBookRepository)(nil).SelectAll), arg0, arg1)
+}
+
+// Update mocks base method
+func (m *MockBookRepository) Update(arg0 context.Context, arg1 *api.Book) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockBookRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), arg0, arg1)
+}
+
+// Delete mocks base method
+func (m *MockBookRepository) Delete(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockBookRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepository)(nil).Delete), arg0, arg1)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	api "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repository "github.com/smockoro/grpc-microservice-sample/pkg/service/item/repository"
+	reflect "reflect"
+)
+
+// MockItemRepository is a mock of ItemRepository interface
+type MockItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemRepositoryMockRecorder
+}
+
+// MockItemRepositoryMockRecorder is the mock recorder for MockItemRepository
+type MockItemRepositoryMockRecorder struct {
+	mock *MockItemRepository
+}
+
+// NewMockItemRepository creates a new mock instance
+func NewMockItemRepository(ctrl *gomock.Controller) *MockItemRepository {
+	mock := &MockItemRepository{ctrl: ctrl}
+	mock.recorder = &MockItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockItemRepository) EXPECT() *MockItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockItemRepository) Insert(arg0 context.Context, arg1 *api.Item) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockItemRepositoryMockRecorder) Insert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockItemRepository)(nil).Insert), arg0, arg1)
+}
+
+// SelectByID mocks base method
+func (m *MockItemRepository) SelectByID(arg0 context.Context, arg1 int64) (*api.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByID", arg0, arg1)
+	ret0, _ := ret[0].(*api.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByID indicates an expected call of SelectByID
+func (mr *MockItemRepositoryMockRecorder) SelectByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockItemRepository)(nil).SelectByID), arg0, arg1)
+}
+
+// SelectAll mocks base method
+func (m *MockItemRepository) SelectAll(arg0 context.Context, arg1 repository.Filter, arg2 repository.Order, arg3 repository.Page) ([]*api.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*api.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAll indicates an expected call of SelectAll
+func (mr *MockItemRepositoryMockRecorder) SelectAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockItemRepository)(nil).SelectAll), arg0, arg1, arg2, arg3)
+}
+
+// SelectEach mocks base method
+func (m *MockItemRepository) SelectEach(arg0 context.Context, arg1 repository.Filter, arg2 repository.Order, arg3 func(*api.Item) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectEach", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SelectEach indicates an expected call of SelectEach
+func (mr *MockItemRepositoryMockRecorder) SelectEach(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectEach", reflect.TypeOf((*MockItemRepository)(nil).SelectEach), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method
+func (m *MockItemRepository) Update(arg0 context.Context, arg1 *api.Item, arg2 []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockItemRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockItemRepository)(nil).Update), arg0, arg1, arg2)
+}
+
+// Delete mocks base method
+func (m *MockItemRepository) Delete(ctx context.Context, id, version int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockItemRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemRepository)(nil).Delete), ctx, id, version)
+}
+
+// InsertBatch mocks base method
+func (m *MockItemRepository) InsertBatch(arg0 context.Context, arg1 []*api.Item) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBatch", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertBatch indicates an expected call of InsertBatch
+func (mr *MockItemRepositoryMockRecorder) InsertBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBatch", reflect.TypeOf((*MockItemRepository)(nil).InsertBatch), arg0, arg1)
+}
+
+// SelectByIDs mocks base method
+func (m *MockItemRepository) SelectByIDs(arg0 context.Context, arg1 []int64) ([]*api.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*api.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByIDs indicates an expected call of SelectByIDs
+func (mr *MockItemRepositoryMockRecorder) SelectByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByIDs", reflect.TypeOf((*MockItemRepository)(nil).SelectByIDs), arg0, arg1)
+}
+
+// DeleteBatch mocks base method
+func (m *MockItemRepository) DeleteBatch(arg0 context.Context, arg1 []repository.Key) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch
+func (mr *MockItemRepositoryMockRecorder) DeleteBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockItemRepository)(nil).DeleteBatch), arg0, arg1)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	api "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repository "github.com/smockoro/grpc-microservice-sample/pkg/service/user/repository"
 	reflect "reflect"
 )
 
@@ -65,46 +66,105 @@ func (mr *MockUserRepositoryMockRecorder) SelectByID(arg0, arg1 interface{}) *go
 }
 
 // SelectAll mocks base method
-func (m *MockUserRepository) SelectAll(arg0 context.Context) ([]*api.User, error) {
+func (m *MockUserRepository) SelectAll(arg0 context.Context, arg1 repository.Filter, arg2 repository.Order, arg3 repository.Page) ([]*api.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectAll", arg0)
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*api.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectAll indicates an expected call of SelectAll
-func (mr *MockUserRepositoryMockRecorder) SelectAll(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) SelectAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockUserRepository)(nil).SelectAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockUserRepository)(nil).SelectAll), arg0, arg1, arg2, arg3)
+}
+
+// SelectEach mocks base method
+func (m *MockUserRepository) SelectEach(arg0 context.Context, arg1 repository.Filter, arg2 repository.Order, arg3 func(*api.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectEach", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SelectEach indicates an expected call of SelectEach
+func (mr *MockUserRepositoryMockRecorder) SelectEach(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectEach", reflect.TypeOf((*MockUserRepository)(nil).SelectEach), arg0, arg1, arg2, arg3)
 }
 
 // Update mocks base method
-func (m *MockUserRepository) Update(arg0 context.Context, arg1 *api.User) (int64, error) {
+func (m *MockUserRepository) Update(arg0 context.Context, arg1 *api.User, arg2 []string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update
-func (mr *MockUserRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), arg0, arg1, arg2)
 }
 
 // Delete mocks base method
-func (m *MockUserRepository) Delete(arg0 context.Context, arg1 int64) (int64, error) {
+func (m *MockUserRepository) Delete(ctx context.Context, id, version int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete
-func (mr *MockUserRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id, version)
+}
+
+// InsertBatch mocks base method
+func (m *MockUserRepository) InsertBatch(arg0 context.Context, arg1 []*api.User) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBatch", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertBatch indicates an expected call of InsertBatch
+func (mr *MockUserRepositoryMockRecorder) InsertBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBatch", reflect.TypeOf((*MockUserRepository)(nil).InsertBatch), arg0, arg1)
+}
+
+// SelectByIDs mocks base method
+func (m *MockUserRepository) SelectByIDs(arg0 context.Context, arg1 []int64) ([]*api.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*api.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByIDs indicates an expected call of SelectByIDs
+func (mr *MockUserRepositoryMockRecorder) SelectByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByIDs", reflect.TypeOf((*MockUserRepository)(nil).SelectByIDs), arg0, arg1)
+}
+
+// DeleteBatch mocks base method
+func (m *MockUserRepository) DeleteBatch(arg0 context.Context, arg1 []repository.Key) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBatch", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBatch indicates an expected call of DeleteBatch
+func (mr *MockUserRepositoryMockRecorder) DeleteBatch(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockUserRepository)(nil).DeleteBatch), arg0, arg1)
 }
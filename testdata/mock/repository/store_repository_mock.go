@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	api "github.com/smockoro/grpc-microservice-sample/pkg/api"
+	repository "github.com/smockoro/grpc-microservice-sample/pkg/service/store/repository"
+	reflect "reflect"
+)
+
+// MockStoreRepository is a mock of StoreRepository interface
+type MockStoreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreRepositoryMockRecorder
+}
+
+// MockStoreRepositoryMockRecorder is the mock recorder for MockStoreRepository
+type MockStoreRepositoryMockRecorder struct {
+	mock *MockStoreRepository
+}
+
+// NewMockStoreRepository creates a new mock instance
+func NewMockStoreRepository(ctrl *gomock.Controller) *MockStoreRepository {
+	mock := &MockStoreRepository{ctrl: ctrl}
+	mock.recorder = &MockStoreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStoreRepository) EXPECT() *MockStoreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method
+func (m *MockStoreRepository) Insert(arg0 context.Context, arg1 *api.Store) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert
+func (mr *MockStoreRepositoryMockRecorder) Insert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockStoreRepository)(nil).Insert), arg0, arg1)
+}
+
+// SelectByID mocks base method
+func (m *MockStoreRepository) SelectByID(arg0 context.Context, arg1 int64) (*api.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectByID", arg0, arg1)
+	ret0, _ := ret[0].(*api.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectByID indicates an expected call of SelectByID
+func (mr *MockStoreRepositoryMockRecorder) SelectByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockStoreRepository)(nil).SelectByID), arg0, arg1)
+}
+
+// SelectAll mocks base method
+func (m *MockStoreRepository) SelectAll(arg0 context.Context, arg1 repository.Page) ([]*api.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1)
+	ret0, _ := ret[0].([]*api.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAll indicates an expected call of SelectAll
+func (mr *MockStoreRepositoryMockRecorder) SelectAll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockStoreRepository)(nil).SelectAll), arg0, arg1)
+}
+
+// Update mocks base method
+func (m *MockStoreRepository) Update(arg0 context.Context, arg1 *api.Store) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockStoreRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStoreRepository)(nil).Update), arg0, arg1)
+}
+
+// Delete mocks base method
+func (m *MockStoreRepository) Delete(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockStoreRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStoreRepository)(nil).Delete), arg0, arg1)
+}
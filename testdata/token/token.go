@@ -0,0 +1,43 @@
+// Package token signs the bearer tokens and writes the policies the
+// server tests authenticate and authorize with.
+package token
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Secret : JWT_SECRET of the servers under test
+const Secret = "0123456789abcdef0123456789abcdef"
+
+// Sign : HS256 token of claims signed with Secret. exp is set one minute
+// ahead unless claims have one.
+func Sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	c := jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix()}
+	for k, v := range claims {
+		c[k] = v
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(Secret))
+	if err != nil {
+		t.Fatalf("err %s", err)
+	}
+	return token
+}
+
+// Policy : path of a policy file holding policy
+func Policy(t *testing.T, policy string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := ioutil.WriteFile(path, []byte(policy), 0600); err != nil {
+		t.Fatalf("err %s", err)
+	}
+	return path
+}
+
+// AllowAll : policy allowing every authenticated caller every method
+const AllowAll = `{"rules": [{"methods": ["*"], "roles": ["*"]}]}`